@@ -0,0 +1,247 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// Rate sets the download speed limit in kilobytes per second. 0 removes the
+// limit.
+func (n NZBGet) Rate(limit int) (bool, error) {
+	var ok bool
+	err := n.call("rate", &ok, limit)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// BandwidthProfile is a speed limit applied during a time of day.
+type BandwidthProfile struct {
+	// Name identifies the profile in events.
+	Name string
+
+	// Days are the days the profile starts on. Empty means every day.
+	Days []time.Weekday
+
+	// Start and End are the times of day the profile is active, as offsets
+	// from midnight. If End is before Start the profile runs over midnight
+	// into the next day; if both are equal it is active all day.
+	Start, End time.Duration
+
+	// Limit is the download speed limit per second. 0 means unlimited. The
+	// limit is applied in whole KB/s, rounded down but to at least 1 KB/s.
+	Limit Size
+}
+
+// ActiveAt reports whether the profile is active at the given time.
+func (p BandwidthProfile) ActiveAt(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	switch {
+	case p.Start == p.End:
+		return p.onDay(t.Weekday())
+	case p.Start < p.End:
+		return p.onDay(t.Weekday()) && offset >= p.Start && offset < p.End
+	}
+	if offset >= p.Start {
+		return p.onDay(t.Weekday())
+	}
+	return offset < p.End && p.onDay(midnight.AddDate(0, 0, -1).Weekday())
+}
+
+func (p BandwidthProfile) onDay(day time.Weekday) bool {
+	if len(p.Days) == 0 {
+		return true
+	}
+	for _, d := range p.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Weekdays are the days from Monday to Friday, for use in
+// BandwidthProfile.Days.
+var Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// BandwidthAction is what a BandwidthScheduler did or noticed
+type BandwidthAction string
+
+// Actions of a BandwidthScheduler
+const (
+	// BandwidthApplied is sent when the limit of a profile was applied.
+	BandwidthApplied BandwidthAction = "APPLIED"
+
+	// BandwidthOverridden is sent when the limit was changed by someone else.
+	// The scheduler leaves it alone until the next profile boundary.
+	BandwidthOverridden BandwidthAction = "OVERRIDDEN"
+)
+
+// BandwidthEvent reports an action of a BandwidthScheduler.
+type BandwidthEvent struct {
+	// Time is the time of the action.
+	Time time.Time
+
+	// Action is what happened.
+	Action BandwidthAction
+
+	// Profile is the name of the active profile, or empty if the default
+	// limit applies.
+	Profile string
+
+	// Limit is the limit applied or, for BandwidthOverridden, the limit found
+	// on the server.
+	Limit Size
+
+	// Err is the error returned by the server when applying the limit.
+	Err error
+}
+
+// BandwidthOption configures a BandwidthScheduler
+type BandwidthOption func(*BandwidthScheduler)
+
+// WithBandwidthInterval sets how often the schedule is checked. The default
+// is one minute.
+func WithBandwidthInterval(interval time.Duration) BandwidthOption {
+	return func(s *BandwidthScheduler) {
+		s.interval = interval
+	}
+}
+
+// WithBandwidthDefault sets the limit applied when no profile is active. The
+// default is unlimited.
+func WithBandwidthDefault(limit Size) BandwidthOption {
+	return func(s *BandwidthScheduler) {
+		s.defaultLimit = limit
+	}
+}
+
+// WithBandwidthClock sets the function returning the current time, whose
+// location is used for the times of day. The default is time.Now.
+func WithBandwidthClock(now func() time.Time) BandwidthOption {
+	return func(s *BandwidthScheduler) {
+		s.now = now
+	}
+}
+
+// BandwidthScheduler applies the speed limits of time-of-day profiles
+// through Rate. The first active profile in the list wins; when none is
+// active the default limit applies.
+//
+// A limit found on the server which differs from the one applied is taken as
+// a manual override and left alone until the active profile changes.
+type BandwidthScheduler struct {
+	client       *NZBGet
+	profiles     []BandwidthProfile
+	interval     time.Duration
+	defaultLimit Size
+	now          func() time.Time
+	events       chan BandwidthEvent
+
+	started    bool
+	active     int
+	applied    int
+	overridden bool
+}
+
+// NewBandwidthScheduler returns a scheduler applying the profiles with the
+// given client.
+func NewBandwidthScheduler(client *NZBGet, profiles []BandwidthProfile, opts ...BandwidthOption) *BandwidthScheduler {
+	s := &BandwidthScheduler{
+		client:   client,
+		profiles: profiles,
+		interval: time.Minute,
+		now:      time.Now,
+		events:   make(chan BandwidthEvent, 100),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Events returns the channel the actions of the scheduler are sent on. It is
+// closed when Run returns.
+func (s *BandwidthScheduler) Events() <-chan BandwidthEvent {
+	return s.events
+}
+
+// Profile returns the profile active at the given time, or nil if the default
+// limit applies.
+func (s *BandwidthScheduler) Profile(t time.Time) *BandwidthProfile {
+	if index := s.activeIndex(t); index >= 0 {
+		return &s.profiles[index]
+	}
+	return nil
+}
+
+func (s *BandwidthScheduler) activeIndex(t time.Time) int {
+	for i, profile := range s.profiles {
+		if profile.ActiveAt(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Run applies the schedule until the context is done and returns the context
+// error. A profile boundary passed while the status cannot be fetched is
+// applied on the first round that succeeds.
+func (s *BandwidthScheduler) Run(ctx context.Context) error {
+	defer close(s.events)
+	return pollEvery(ctx, s.interval, func() {
+		status, err := s.client.Status()
+		if err != nil {
+			return
+		}
+		if event, ok := s.check(*status); ok {
+			select {
+			case s.events <- event:
+			default:
+			}
+		}
+	})
+}
+
+// check applies the limit at a profile boundary, or notices an override,
+// and reports what happened, if anything.
+func (s *BandwidthScheduler) check(status Status) (BandwidthEvent, bool) {
+	now := s.now()
+	active := s.activeIndex(now)
+	event := BandwidthEvent{Time: now}
+	if active >= 0 {
+		event.Profile = s.profiles[active].Name
+	}
+
+	if !s.started || active != s.active {
+		limit := s.defaultLimit
+		if active >= 0 {
+			limit = s.profiles[active].Limit
+		}
+		kb := int(limit / Kilobyte)
+		if limit > 0 && kb == 0 {
+			// Rate(0) would lift the limit.
+			kb = 1
+		}
+		event.Action = BandwidthApplied
+		event.Limit = Size(kb) * Kilobyte
+		event.Err = accepted(s.client.Rate(kb))
+		if event.Err == nil {
+			s.started = true
+			s.active = active
+			s.applied = kb * int(Kilobyte)
+			s.overridden = false
+		}
+		return event, true
+	}
+
+	if !s.overridden && status.DownloadLimit != s.applied {
+		s.overridden = true
+		event.Action = BandwidthOverridden
+		event.Limit = Size(status.DownloadLimit)
+		return event, true
+	}
+	return event, false
+}
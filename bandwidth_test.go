@@ -0,0 +1,161 @@
+package nzbget_test
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockRate(limit int) {
+	gock.New(nzbgetURL).
+		Post("/jsonrpc").
+		JSON(map[string]interface{}{"method": "rate", "params": []int{limit}}).
+		Reply(200).
+		JSON(`{"version": "1.1", "result": true}`)
+}
+
+func mockDownloadLimit(limit int) {
+	gock.New(nzbgetURL).
+		Get("/jsonrpc/status").
+		Reply(200).
+		JSON(`{"version": "1.1", "result": {"DownloadLimit": ` + strconv.Itoa(limit) + `}}`)
+}
+
+// at returns the time on 1 January 2024, a Monday, plus the given offset.
+func at(offset time.Duration) time.Time {
+	return time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Add(offset)
+}
+
+var _ = Describe("BandwidthProfile", func() {
+	work := nzbget.BandwidthProfile{Days: nzbget.Weekdays, Start: 8 * time.Hour, End: 18 * time.Hour}
+	fridayNight := nzbget.BandwidthProfile{Days: []time.Weekday{time.Friday}, Start: 22 * time.Hour, End: 6 * time.Hour}
+	always := nzbget.BandwidthProfile{}
+
+	It("should be active during its hours", func() {
+		Expect(work.ActiveAt(at(8 * time.Hour))).To(BeTrue())
+		Expect(work.ActiveAt(at(18*time.Hour - time.Second))).To(BeTrue())
+		Expect(work.ActiveAt(at(18 * time.Hour))).To(BeFalse())
+		Expect(work.ActiveAt(at(5*24*time.Hour + 9*time.Hour))).To(BeFalse())
+	})
+
+	It("should run over midnight", func() {
+		friday := 4 * 24 * time.Hour
+		Expect(fridayNight.ActiveAt(at(friday + 23*time.Hour))).To(BeTrue())
+		Expect(fridayNight.ActiveAt(at(friday + 29*time.Hour))).To(BeTrue())
+		Expect(fridayNight.ActiveAt(at(friday + 30*time.Hour))).To(BeFalse())
+		Expect(fridayNight.ActiveAt(at(friday + 47*time.Hour))).To(BeFalse())
+		Expect(fridayNight.ActiveAt(at(time.Hour))).To(BeFalse())
+	})
+
+	It("should be active all day without hours", func() {
+		Expect(always.ActiveAt(at(0))).To(BeTrue())
+		Expect(always.ActiveAt(at(23 * time.Hour))).To(BeTrue())
+	})
+})
+
+var _ = Describe("BandwidthScheduler", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	profiles := []nzbget.BandwidthProfile{
+		{Name: "work", Days: nzbget.Weekdays, Start: 8 * time.Hour, End: 18 * time.Hour, Limit: 2 * nzbget.Megabyte},
+		{Name: "night", Start: 22 * time.Hour, End: 6 * time.Hour},
+	}
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive())
+		gock.Off()
+	})
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	It("should apply the profiles and respect overrides until the next boundary", func() {
+		mockDownloadLimit(0)
+		mockRate(2048)
+		mockDownloadLimit(2097152)
+		mockDownloadLimit(512000)
+		mockDownloadLimit(512000)
+		mockDownloadLimit(512000)
+		mockRate(1024)
+		mockDownloadLimit(1048576)
+		mockRate(0)
+
+		times := []time.Time{
+			at(9 * time.Hour),
+			at(10 * time.Hour),
+			at(11 * time.Hour),
+			at(12 * time.Hour),
+			at(18*time.Hour + 30*time.Minute),
+			at(25 * time.Hour),
+		}
+		clock := func() time.Time {
+			now := times[0]
+			if len(times) > 1 {
+				times = times[1:]
+			}
+			return now
+		}
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		scheduler := nzbget.NewBandwidthScheduler(client, profiles,
+			nzbget.WithBandwidthInterval(time.Millisecond),
+			nzbget.WithBandwidthDefault(nzbget.Megabyte),
+			nzbget.WithBandwidthClock(clock))
+		Expect(scheduler.Profile(at(9 * time.Hour)).Name).To(Equal("work"))
+		Expect(scheduler.Profile(at(19 * time.Hour))).To(BeNil())
+
+		done = start(ctx, scheduler)
+
+		events := receive(scheduler.Events(), 4).([]nzbget.BandwidthEvent)
+		Expect(events[0]).To(MatchFields(IgnoreExtras, Fields{
+			"Action":  Equal(nzbget.BandwidthApplied),
+			"Profile": Equal("work"),
+			"Limit":   Equal(2 * nzbget.Megabyte),
+			"Err":     BeNil(),
+		}))
+		Expect(events[1]).To(MatchFields(IgnoreExtras, Fields{
+			"Action":  Equal(nzbget.BandwidthOverridden),
+			"Profile": Equal("work"),
+			"Limit":   Equal(500 * nzbget.Kilobyte),
+		}))
+		Expect(events[2]).To(MatchFields(IgnoreExtras, Fields{
+			"Action":  Equal(nzbget.BandwidthApplied),
+			"Profile": BeEmpty(),
+			"Limit":   Equal(nzbget.Megabyte),
+		}))
+		Expect(events[3]).To(MatchFields(IgnoreExtras, Fields{
+			"Action":  Equal(nzbget.BandwidthApplied),
+			"Profile": Equal("night"),
+			"Limit":   BeZero(),
+		}))
+	})
+
+	It("should not lift the limit for limits below 1 KB/s", func() {
+		mockDownloadLimit(0)
+		mockRate(1)
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		scheduler := nzbget.NewBandwidthScheduler(client, []nzbget.BandwidthProfile{{Name: "crawl", Limit: 512}},
+			nzbget.WithBandwidthInterval(time.Millisecond),
+			nzbget.WithBandwidthClock(func() time.Time { return at(0) }))
+		done = start(ctx, scheduler)
+
+		events := receive(scheduler.Events(), 1).([]nzbget.BandwidthEvent)
+		Expect(events[0].Limit).To(Equal(nzbget.Kilobyte))
+		Expect(events[0].Err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,104 @@
+package nzbget
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrItemNotFound is returned by WaitForCompletion when the item is neither in
+// the download queue nor in history.
+var ErrItemNotFound = errors.New("nzbget: item not found in queue or history")
+
+// missingPolls is the number of consecutive polls an item may be missing from
+// both queue and history before WaitForCompletion gives up. An item moving
+// from the queue into history can briefly be in neither.
+const missingPolls = 3
+
+// CompletionOption configures WaitForCompletion
+type CompletionOption func(*completionOptions)
+
+type completionOptions struct {
+	interval   time.Duration
+	onProgress func(FileGroup)
+}
+
+// WithCompletionInterval sets how often the queue and history are polled. The
+// default is one second.
+func WithCompletionInterval(interval time.Duration) CompletionOption {
+	return func(o *completionOptions) {
+		o.interval = interval
+	}
+}
+
+// WithCompletionProgress registers a function receiving the state of the
+// group on each poll while it is in the queue, including post-processing.
+// Use FileGroup.Progress and FileGroup.PostProgress for the completed share.
+func WithCompletionProgress(onProgress func(FileGroup)) CompletionOption {
+	return func(o *completionOptions) {
+		o.onProgress = onProgress
+	}
+}
+
+// WaitForCompletion waits until the item with the given NZBID has left the
+// download queue and returns its history entry. Use HistoricalEntry.Outcome
+// to tell whether it succeeded. Errors while polling are ignored so the wait
+// survives restarts of the server; use the context to bound the wait.
+func (n NZBGet) WaitForCompletion(ctx context.Context, nzbID int, opts ...CompletionOption) (*HistoricalEntry, error) {
+	options := completionOptions{interval: time.Second}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	missing := 0
+	for {
+		entry, found, err := n.pollCompletion(nzbID, options.onProgress)
+		switch {
+		case entry != nil:
+			return entry, nil
+		case found || err != nil:
+			missing = 0
+		default:
+			missing++
+			if missing >= missingPolls {
+				return nil, ErrItemNotFound
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollCompletion looks for the item in the queue, then in history. It returns
+// the history entry once the item is finished and whether it was found at all.
+func (n NZBGet) pollCompletion(nzbID int, onProgress func(FileGroup)) (*HistoricalEntry, bool, error) {
+	groups, err := n.FileGroups()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, group := range groups {
+		if group.NZBID == nzbID {
+			if onProgress != nil {
+				onProgress(group)
+			}
+			return nil, true, nil
+		}
+	}
+
+	history, err := n.History()
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range history {
+		if history[i].NZBID == nzbID {
+			return &history[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
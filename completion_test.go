@@ -0,0 +1,100 @@
+package nzbget_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#WaitForCompletion", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		Context("finished", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/listgroups").
+					Reply(200).
+					JSON(`{"version": "1.1", "result": [{"NZBID": 1, "Status": "DOWNLOADING", "FileSizeLo": 100, "RemainingSizeLo": 50}]}`)
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/listgroups").
+					Reply(500)
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/listgroups").
+					Reply(200).
+					JSON(`{"version": "1.1", "result": [{"NZBID": 1, "Status": "PP_QUEUED", "FileSizeLo": 100}]}`)
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/listgroups").
+					Reply(200).
+					JSON(`{"version": "1.1", "result": []}`)
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/history").
+					Reply(200).
+					JSON(watchedHistory)
+			})
+
+			It("should return the history entry and report progress", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				var progress []nzbget.GroupStatus
+				entry, err := client.WaitForCompletion(context.Background(), 1,
+					nzbget.WithCompletionInterval(time.Millisecond),
+					nzbget.WithCompletionProgress(func(group nzbget.FileGroup) {
+						progress = append(progress, group.Status)
+					}))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(entry.NZBID).To(Equal(1))
+				Expect(entry.Outcome().Category).To(Equal(nzbget.OutcomeSuccess))
+				Expect(progress).To(Equal([]nzbget.GroupStatus{nzbget.GroupStatusDownloading, nzbget.GroupStatusPPQueued}))
+			})
+		})
+
+		Context("unknown item", func() {
+			BeforeEach(func() {
+				for i := 0; i < 3; i++ {
+					gock.New(nzbgetURL).
+						Get("/jsonrpc/listgroups").
+						Reply(200).
+						JSON(`{"version": "1.1", "result": []}`)
+					gock.New(nzbgetURL).
+						Get("/jsonrpc/history").
+						Reply(200).
+						JSON(`{"version": "1.1", "result": []}`)
+				}
+			})
+
+			It("should give up", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.WaitForCompletion(context.Background(), 7, nzbget.WithCompletionInterval(time.Millisecond))
+				Expect(err).To(Equal(nzbget.ErrItemNotFound))
+			})
+		})
+
+		Context("cancelled", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/listgroups").
+					Persist().
+					Reply(200).
+					JSON(`{"version": "1.1", "result": [{"NZBID": 1, "Status": "QUEUED"}]}`)
+			})
+
+			It("should return the context error", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				defer cancel()
+				_, err = client.WaitForCompletion(ctx, 1, nzbget.WithCompletionInterval(time.Millisecond))
+				Expect(err).To(Equal(context.DeadlineExceeded))
+			})
+		})
+	})
+})
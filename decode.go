@@ -0,0 +1,193 @@
+package nzbget
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The response types decode field by field so that a single field of an
+// unexpected type does not fail the whole call. Numbers and booleans sent as
+// strings, and the other way round, are converted. Keys without a matching
+// field, and values which could not be converted, are kept in the Extra map of
+// the response type.
+
+// UnmarshalJSON decodes the status, keeping unknown fields in Extra.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	type status Status
+	extra, err := decodeObject(data, (*status)(s))
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
+}
+
+// UnmarshalJSON decodes the volume statistics, keeping unknown fields in
+// Extra.
+func (v *ServerVolume) UnmarshalJSON(data []byte) error {
+	type serverVolume ServerVolume
+	extra, err := decodeObject(data, (*serverVolume)(v))
+	if err != nil {
+		return err
+	}
+	v.Extra = extra
+	return nil
+}
+
+// decodeObject decodes the JSON object data into the struct pointed to by v
+// and returns the entries which could not be stored in it.
+func decodeObject(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	extra, _, err := decodeFields(data, v)
+	return extra, err
+}
+
+// decodeFields is decodeObject which also returns the names of the struct
+// fields a value was stored in, for normalization which needs to tell a
+// missing key from a zero value.
+func decodeFields(data []byte, v interface{}) (map[string]json.RawMessage, map[string]bool, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, nil, err
+	}
+
+	target := reflect.ValueOf(v).Elem()
+	fields := jsonFields(target.Type())
+	var extra map[string]json.RawMessage
+	decoded := map[string]bool{}
+	for key, raw := range object {
+		index, ok := fields[key]
+		if !ok {
+			index, ok = fields[strings.ToLower(key)]
+		}
+		if ok && decodeValue(raw, target.Field(index)) {
+			decoded[target.Type().Field(index).Name] = true
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[key] = raw
+	}
+	return extra, decoded, nil
+}
+
+// jsonFields maps the JSON names of the exported fields of struct type t to
+// their index. Names are also registered in lower case, as encoding/json
+// matches keys case-insensitively.
+func jsonFields(t reflect.Type) map[string]int {
+	fields := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tag = strings.Split(tag, ",")[0]
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields[name] = i
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = i
+		}
+	}
+	return fields
+}
+
+// decodeValue stores raw into value, converting between strings, numbers
+// and booleans if needed. It reports whether the value could be stored.
+func decodeValue(raw json.RawMessage, value reflect.Value) bool {
+	if json.Unmarshal(raw, value.Addr().Interface()) == nil {
+		return true
+	}
+	value.Set(reflect.Zero(value.Type()))
+	return convertValue(raw, value)
+}
+
+// convertValue stores the string, number or boolean raw into a value of a
+// different basic kind.
+func convertValue(raw json.RawMessage, value reflect.Value) bool {
+	var decoded interface{}
+	if json.Unmarshal(raw, &decoded) != nil {
+		return false
+	}
+	text := ""
+	switch d := decoded.(type) {
+	case string:
+		text = strings.TrimSpace(d)
+	case float64, bool:
+		text = string(raw)
+	default:
+		return false
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+		return true
+	case reflect.Bool:
+		b, ok := parseBool(text)
+		if ok {
+			value.SetBool(b)
+		}
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := parseNumber(text)
+		if ok && !value.OverflowInt(int64(f)) {
+			value.SetInt(int64(f))
+			return true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := parseNumber(text)
+		if ok && f >= 0 && !value.OverflowUint(uint64(f)) {
+			value.SetUint(uint64(f))
+			return true
+		}
+	case reflect.Float32, reflect.Float64:
+		f, ok := parseNumber(text)
+		if ok {
+			value.SetFloat(f)
+		}
+		return ok
+	}
+	return false
+}
+
+// parseNumber parses a number which may also be given as a boolean.
+func parseNumber(text string) (float64, bool) {
+	if b, ok := parseBool(text); ok && !isNumeric(text) {
+		if b {
+			return 1, true
+		}
+		return 0, true
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	return f, err == nil
+}
+
+// parseBool parses a boolean in any of the spellings NZBGet uses in its
+// configuration and API: true/false, yes/no and numbers.
+func parseBool(text string) (bool, bool) {
+	switch strings.ToLower(text) {
+	case "true", "yes":
+		return true, true
+	case "false", "no", "":
+		return false, true
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f != 0, true
+	}
+	return false, false
+}
+
+func isNumeric(text string) bool {
+	_, err := strconv.ParseFloat(text, 64)
+	return err == nil
+}
@@ -0,0 +1,108 @@
+package nzbget_test
+
+import (
+	"encoding/json"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Decoding", func() {
+
+	It("should keep unknown fields of the status", func() {
+		var status nzbget.Status
+		err := json.Unmarshal([]byte(`{"DownloadRate": 1024, "ExtraDiskSpaceMB": 20, "Shiny": {"New": true}}`), &status)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.DownloadRate).To(Equal(1024))
+		Expect(status.Extra).To(HaveLen(2))
+		Expect(string(status.Extra["ExtraDiskSpaceMB"])).To(Equal("20"))
+		Expect(string(status.Extra["Shiny"])).To(Equal(`{"New": true}`))
+	})
+
+	It("should convert values of changed types", func() {
+		var status nzbget.Status
+		err := json.Unmarshal([]byte(`{
+			"DownloadRate": "2048",
+			"DownloadLimit": 1.0e3,
+			"DownloadPaused": "True",
+			"PostPaused": 1,
+			"ScanPaused": "no",
+			"UpTimeSec": true
+		}`), &status)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(MatchFields(IgnoreExtras, Fields{
+			"DownloadRate":   Equal(2048),
+			"DownloadLimit":  Equal(1000),
+			"DownloadPaused": BeTrue(),
+			"PostPaused":     BeTrue(),
+			"ScanPaused":     BeFalse(),
+			"UpTimeSec":      Equal(1),
+			"Extra":          BeNil(),
+		}))
+	})
+
+	It("should convert numbers sent for strings", func() {
+		var group nzbget.FileGroup
+		err := json.Unmarshal([]byte(`{"NZBID": "17", "Category": 5, "Status": "QUEUED"}`), &group)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(group.NZBID).To(Equal(17))
+		Expect(group.Category).To(Equal("5"))
+		Expect(group.Status).To(Equal(nzbget.GroupStatusQueued))
+	})
+
+	It("should keep values which cannot be converted", func() {
+		var entry nzbget.HistoricalEntry
+		err := json.Unmarshal([]byte(`{
+			"NZBID": 12,
+			"Health": "unknown",
+			"ServerStats": {"ServerID": 1},
+			"Parameters": [{"Name": "*Unpack:", "Value": "yes"}]
+		}`), &entry)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entry.NZBID).To(Equal(12))
+		Expect(entry.Health).To(BeZero())
+		Expect(entry.ServerStats).To(BeNil())
+		Expect(entry.Parameters.Get("*Unpack:")).To(Equal("yes"))
+		Expect(entry.Extra).To(HaveKey("Health"))
+		Expect(entry.Extra).To(HaveKey("ServerStats"))
+	})
+
+	It("should keep unknown fields of server volumes", func() {
+		var volume nzbget.ServerVolume
+		err := json.Unmarshal([]byte(`{"ServerID": "2", "CountersResetTime": 1589514272}`), &volume)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(volume.ServerID).To(Equal(2))
+		Expect(volume.Extra).To(HaveKey("CountersResetTime"))
+	})
+
+	It("should still fail for responses which are no objects", func() {
+		var group nzbget.FileGroup
+		Expect(json.Unmarshal([]byte(`[1, 2]`), &group)).To(HaveOccurred())
+	})
+
+	Context("file groups", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/listgroups").
+				Reply(200).
+				JSON(listGroups)
+		})
+
+		It("should not report modelled fields as extra", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			groups, err := client.FileGroups()
+			Expect(err).ToNot(HaveOccurred())
+			for _, group := range groups {
+				Expect(group.Extra).To(BeNil())
+			}
+		})
+	})
+})
@@ -0,0 +1,198 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// DiskGuardAction is what a DiskGuard did
+type DiskGuardAction string
+
+// Actions of a DiskGuard
+const (
+	DiskGuardPaused  DiskGuardAction = "PAUSED"
+	DiskGuardResumed DiskGuardAction = "RESUMED"
+)
+
+// DiskGuardEvent reports a pause or resume by a DiskGuard.
+type DiskGuardEvent struct {
+	// Time is the time of the action.
+	Time time.Time
+
+	// Action is what the guard did.
+	Action DiskGuardAction
+
+	// Post is set if post-processing was paused or resumed as well.
+	Post bool
+
+	// Free is the free disk space at the time of the action.
+	Free Size
+
+	// Required is the free disk space below which downloading is paused.
+	Required Size
+
+	// Err is the error returned by the server when carrying out the action.
+	Err error
+}
+
+// DiskGuardOption configures a DiskGuard
+type DiskGuardOption func(*DiskGuard)
+
+// WithDiskInterval sets how often the free disk space is checked. The
+// default is 30 seconds.
+func WithDiskInterval(interval time.Duration) DiskGuardOption {
+	return func(g *DiskGuard) {
+		g.interval = interval
+	}
+}
+
+// WithDiskThreshold sets the free disk space below which downloading is
+// paused. The default is one gigabyte.
+func WithDiskThreshold(threshold Size) DiskGuardOption {
+	return func(g *DiskGuard) {
+		g.threshold = threshold
+	}
+}
+
+// WithDiskHysteresis sets how much the free disk space has to rise above
+// the required space before downloading is resumed. The default is 512
+// megabytes.
+func WithDiskHysteresis(hysteresis Size) DiskGuardOption {
+	return func(g *DiskGuard) {
+		g.hysteresis = hysteresis
+	}
+}
+
+// WithDiskQueueCheck sets whether the free disk space has to cover the
+// remaining size of the queue as well. It is enabled by default.
+func WithDiskQueueCheck(enabled bool) DiskGuardOption {
+	return func(g *DiskGuard) {
+		g.queueCheck = enabled
+	}
+}
+
+// WithDiskPausePost sets whether post-processing is paused along with
+// downloading. It is disabled by default.
+func WithDiskPausePost(enabled bool) DiskGuardOption {
+	return func(g *DiskGuard) {
+		g.pausePost = enabled
+	}
+}
+
+// DiskGuard pauses downloading when the free disk space drops below the
+// threshold, or below the remaining size of the queue, and resumes it once
+// the free space has risen above that level plus the hysteresis.
+//
+// The guard only resumes what it paused itself: a queue paused by the user
+// stays paused. If the queue is resumed by someone else while space is still
+// low, the guard pauses it again.
+type DiskGuard struct {
+	client     *NZBGet
+	interval   time.Duration
+	threshold  Size
+	hysteresis Size
+	queueCheck bool
+	pausePost  bool
+	events     chan DiskGuardEvent
+
+	pausedDownload bool
+	pausedPost     bool
+}
+
+// NewDiskGuard returns a disk guard for the given client.
+func NewDiskGuard(client *NZBGet, opts ...DiskGuardOption) *DiskGuard {
+	g := &DiskGuard{
+		client:     client,
+		interval:   30 * time.Second,
+		threshold:  Gigabyte,
+		hysteresis: 512 * Megabyte,
+		queueCheck: true,
+		events:     make(chan DiskGuardEvent, 100),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Events returns the channel the actions of the guard are sent on. It is
+// closed when Run returns.
+func (g *DiskGuard) Events() <-chan DiskGuardEvent {
+	return g.events
+}
+
+// Required returns the free disk space required to keep downloading.
+func (g *DiskGuard) Required(status Status) Size {
+	required := g.threshold
+	if g.queueCheck && status.RemainingSize() > required {
+		required = status.RemainingSize()
+	}
+	return required
+}
+
+// Run watches the free disk space until the context is done and returns the
+// context error. The queue is left as it is while the status cannot be
+// fetched.
+func (g *DiskGuard) Run(ctx context.Context) error {
+	defer close(g.events)
+	return pollEvery(ctx, g.interval, func() {
+		status, err := g.client.Status()
+		if err != nil {
+			return
+		}
+		if event, ok := g.check(*status); ok {
+			select {
+			case g.events <- event:
+			default:
+			}
+		}
+	})
+}
+
+// check pauses or resumes according to the status and reports the action
+// taken, if any.
+func (g *DiskGuard) check(status Status) (DiskGuardEvent, bool) {
+	free, required := status.FreeDiskSpace(), g.Required(status)
+	event := DiskGuardEvent{Time: time.Now(), Free: free, Required: required}
+	switch {
+	case free < required && !status.DownloadPaused:
+		event.Action = DiskGuardPaused
+		event.Err = g.pause(status)
+		event.Post = g.pausedPost
+	case g.pausedDownload && free >= required+g.hysteresis:
+		event.Action = DiskGuardResumed
+		event.Post = g.pausedPost
+		event.Err = g.resume()
+	default:
+		return event, false
+	}
+	return event, true
+}
+
+func (g *DiskGuard) pause(status Status) error {
+	if err := accepted(g.client.PauseDownload()); err != nil {
+		return err
+	}
+	g.pausedDownload = true
+	if g.pausePost && !status.PostPaused {
+		if err := accepted(g.client.PausePost()); err != nil {
+			return err
+		}
+		g.pausedPost = true
+	}
+	return nil
+}
+
+func (g *DiskGuard) resume() error {
+	if g.pausedPost {
+		if err := accepted(g.client.ResumePost()); err != nil {
+			return err
+		}
+		g.pausedPost = false
+	}
+	if err := accepted(g.client.ResumeDownload()); err != nil {
+		return err
+	}
+	g.pausedDownload = false
+	return nil
+}
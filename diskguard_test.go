@@ -0,0 +1,110 @@
+package nzbget_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockDiskStatus(free, remaining nzbget.Size, downloadPaused bool) {
+	gock.New(nzbgetURL).
+		Get("/jsonrpc/status").
+		Reply(200).
+		JSON(fmt.Sprintf(`{"version": "1.1", "result": {
+			"FreeDiskSpaceHi": %d, "FreeDiskSpaceLo": %d,
+			"RemainingSizeHi": %d, "RemainingSizeLo": %d,
+			"DownloadPaused": %t}}`,
+			free>>32, free&0xffffffff, remaining>>32, remaining&0xffffffff, downloadPaused))
+}
+
+func mockCommand(method string) {
+	gock.New(nzbgetURL).
+		Get("/jsonrpc/" + method).
+		Reply(200).
+		JSON(`{"version": "1.1", "result": true}`)
+}
+
+var _ = Describe("DiskGuard", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	AfterEach(func() {
+		cancel()
+		if done != nil {
+			Eventually(done).Should(Receive())
+		}
+		gock.Off()
+	})
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		done = nil
+	})
+
+	It("should require space for the remaining queue", func() {
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		guard := nzbget.NewDiskGuard(client)
+		Expect(guard.Required(nzbget.Status{RemainingSizeLo: 1024})).To(Equal(nzbget.Gigabyte))
+		Expect(guard.Required(nzbget.Status{RemainingSizeHi: 1})).To(Equal(4 * nzbget.Gigabyte))
+		guard = nzbget.NewDiskGuard(client, nzbget.WithDiskQueueCheck(false))
+		Expect(guard.Required(nzbget.Status{RemainingSizeHi: 1})).To(Equal(nzbget.Gigabyte))
+	})
+
+	It("should pause on low space and resume after the hysteresis", func() {
+		mockDiskStatus(10*nzbget.Gigabyte, 2*nzbget.Gigabyte, false)
+		mockDiskStatus(1536*nzbget.Megabyte, 2*nzbget.Gigabyte, false)
+		mockCommand("pausedownload")
+		mockCommand("pausepost")
+		mockDiskStatus(2200*nzbget.Megabyte, 2*nzbget.Gigabyte, true)
+		mockDiskStatus(3*nzbget.Gigabyte, 2*nzbget.Gigabyte, true)
+		mockCommand("resumepost")
+		mockCommand("resumedownload")
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		guard := nzbget.NewDiskGuard(client,
+			nzbget.WithDiskInterval(time.Millisecond),
+			nzbget.WithDiskPausePost(true))
+		done = start(ctx, guard)
+
+		events := receive(guard.Events(), 2).([]nzbget.DiskGuardEvent)
+		Expect(events[0]).To(MatchFields(IgnoreExtras, Fields{
+			"Action":   Equal(nzbget.DiskGuardPaused),
+			"Post":     BeTrue(),
+			"Free":     Equal(1536 * nzbget.Megabyte),
+			"Required": Equal(2 * nzbget.Gigabyte),
+			"Err":      BeNil(),
+		}))
+		Expect(events[1]).To(MatchFields(IgnoreExtras, Fields{
+			"Action": Equal(nzbget.DiskGuardResumed),
+			"Post":   BeTrue(),
+			"Free":   Equal(3 * nzbget.Gigabyte),
+			"Err":    BeNil(),
+		}))
+		Expect(gock.IsDone()).To(BeTrue())
+	})
+
+	It("should not resume a queue paused by someone else", func() {
+		mockDiskStatus(10*nzbget.Gigabyte, 0, true)
+		mockDiskStatus(500*nzbget.Megabyte, 0, true)
+		mockDiskStatus(10*nzbget.Gigabyte, 0, true)
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		guard := nzbget.NewDiskGuard(client, nzbget.WithDiskInterval(time.Millisecond))
+		done = start(ctx, guard)
+
+		Eventually(gock.IsDone).Should(BeTrue())
+		Consistently(guard.Events(), 20*time.Millisecond).ShouldNot(Receive())
+	})
+})
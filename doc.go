@@ -0,0 +1,9 @@
+// Package nzbget is a client for the JSON-RPC API of NZBGet.
+//
+// Besides the API methods the package has monitors, such as HealthMonitor,
+// which poll the server from Run until their context is done. They report
+// what they do on a buffered channel but never wait for it to be read: while
+// the buffer is full further values are dropped, so a monitor nobody listens
+// to keeps working. Where a complete record matters, a monitor takes a
+// writer instead.
+package nzbget
@@ -0,0 +1,232 @@
+package nzbget
+
+import "sort"
+
+// DupeMode is the duplicate mode of an item, as in FileGroup.DupeMode,
+// HistoricalEntry.DupeMode and FeedItem.DupeMode
+type DupeMode string
+
+// Duplicate modes
+const (
+	DupeModeScore DupeMode = "SCORE"
+	DupeModeAll   DupeMode = "ALL"
+	DupeModeForce DupeMode = "FORCE"
+)
+
+// DupeSet is the queue and history items sharing a duplicate key.
+type DupeSet struct {
+	// Key is the duplicate key.
+	Key string
+
+	// Queued are the items in the download queue, History the items in
+	// history, including hidden ones if they were requested.
+	Queued  []FileGroup
+	History []HistoricalEntry
+
+	// Best is the successful history item with the highest score, the most
+	// recent one on ties, or nil if there is none.
+	Best *HistoricalEntry
+
+	// Good is set if one of the history items was marked as good.
+	Good bool
+
+	// Redundant are the queued items the duplicate check would not download
+	// if they were added now: items in SCORE mode scoring no higher than
+	// Best, and all items but FORCE mode ones once an item was marked as
+	// good.
+	Redundant []FileGroup
+}
+
+// DupeVerdict is what the duplicate check does with a new item
+type DupeVerdict string
+
+// Verdicts of the duplicate check
+const (
+	// DupeAdd means the item is added to the queue and downloaded.
+	DupeAdd DupeVerdict = "ADD"
+
+	// DupeBackup means the item is added to the queue as a backup, to be
+	// downloaded if a queued item with the same key fails.
+	DupeBackup DupeVerdict = "BACKUP"
+
+	// DupeSkip means the item is moved to history as a duplicate without
+	// being downloaded.
+	DupeSkip DupeVerdict = "SKIP"
+)
+
+// DupeExplanation is what the duplicate check would do with a new item and
+// why.
+type DupeExplanation struct {
+	// Verdict is what would happen to the item.
+	Verdict DupeVerdict
+
+	// Reason explains the verdict.
+	Reason string
+
+	// Set is the items sharing the key of the new item.
+	Set DupeSet
+
+	// Replaced are the queued items scoring lower than the new item, which
+	// the server moves to history as duplicates if they have not started
+	// downloading yet.
+	Replaced []FileGroup
+}
+
+// DupeAnalyzer groups queue and history items by duplicate key. Items
+// without a key are left out.
+type DupeAnalyzer struct {
+	sets map[string]*DupeSet
+}
+
+// NewDupeAnalyzer returns an analyzer of the given queue and history.
+func NewDupeAnalyzer(groups []FileGroup, history []HistoricalEntry) *DupeAnalyzer {
+	a := &DupeAnalyzer{sets: map[string]*DupeSet{}}
+	for _, group := range groups {
+		if group.DupeKey != "" {
+			set := a.set(group.DupeKey)
+			set.Queued = append(set.Queued, group)
+		}
+	}
+	for _, entry := range history {
+		if entry.DupeKey != "" {
+			set := a.set(entry.DupeKey)
+			set.History = append(set.History, entry)
+		}
+	}
+	for _, set := range a.sets {
+		set.analyze()
+	}
+	return a
+}
+
+// Dupes fetches the queue and history, including hidden entries, and returns
+// an analyzer of them.
+func (n *NZBGet) Dupes() (*DupeAnalyzer, error) {
+	groups, err := n.FileGroups()
+	if err != nil {
+		return nil, err
+	}
+	history, err := n.History(WithHidden(true))
+	if err != nil {
+		return nil, err
+	}
+	return NewDupeAnalyzer(groups, history), nil
+}
+
+func (a *DupeAnalyzer) set(key string) *DupeSet {
+	set, ok := a.sets[key]
+	if !ok {
+		set = &DupeSet{Key: key}
+		a.sets[key] = set
+	}
+	return set
+}
+
+func (s *DupeSet) analyze() {
+	for i := range s.History {
+		entry := &s.History[i]
+		if DupeMode(entry.DupeMode) == DupeModeForce {
+			continue
+		}
+		outcome := entry.Outcome()
+		if outcome.Category != OutcomeSuccess {
+			continue
+		}
+		if outcome.Reason == "GOOD" {
+			s.Good = true
+		}
+		if s.Best == nil || entry.DupeScore > s.Best.DupeScore ||
+			(entry.DupeScore == s.Best.DupeScore && entry.HistoryTime > s.Best.HistoryTime) {
+			s.Best = entry
+		}
+	}
+	for _, group := range s.Queued {
+		if s.redundant(group.DupeScore, DupeMode(group.DupeMode)) {
+			s.Redundant = append(s.Redundant, group)
+		}
+	}
+}
+
+// redundant reports whether an item with the given score and mode is not
+// needed given the history.
+func (s *DupeSet) redundant(score int, mode DupeMode) bool {
+	switch {
+	case mode == DupeModeForce:
+		return false
+	case s.Good:
+		return true
+	}
+	return mode != DupeModeAll && s.Best != nil && s.Best.DupeScore >= score
+}
+
+// Sets returns the duplicate sets ordered by key.
+func (a *DupeAnalyzer) Sets() []DupeSet {
+	sets := make([]DupeSet, 0, len(a.sets))
+	for _, set := range a.sets {
+		sets = append(sets, *set)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].Key < sets[j].Key
+	})
+	return sets
+}
+
+// Set returns the duplicate set of the key.
+func (a *DupeAnalyzer) Set(key string) (DupeSet, bool) {
+	set, ok := a.sets[key]
+	if !ok {
+		return DupeSet{Key: key}, false
+	}
+	return *set, true
+}
+
+// Explain returns what the duplicate check of the server would do with a new
+// item of the given key, score and mode. FORCE mode items and items without
+// key are always added. Once an item with the key was marked as good, other
+// items are skipped; SCORE mode items are also skipped if a successful item
+// scored at least as high. Items are added as backups if a queued item scores
+// at least as high, otherwise they are added and replace the lower scoring
+// queued items. Items in the queue or history with FORCE mode take no part in
+// the check.
+func (a *DupeAnalyzer) Explain(key string, score int, mode DupeMode) DupeExplanation {
+	set, _ := a.Set(key)
+	explanation := DupeExplanation{Set: set}
+	switch {
+	case key == "":
+		explanation.Verdict, explanation.Reason = DupeAdd, "no duplicate key"
+		return explanation
+	case mode == DupeModeForce:
+		explanation.Verdict, explanation.Reason = DupeAdd, "FORCE mode skips the duplicate check"
+		return explanation
+	case set.Good:
+		explanation.Verdict, explanation.Reason = DupeSkip, "an item with the key was marked as good"
+		return explanation
+	case set.redundant(score, mode):
+		explanation.Verdict, explanation.Reason = DupeSkip, "a successful item has the same or a higher score"
+		return explanation
+	}
+
+	for _, group := range set.Queued {
+		if DupeMode(group.DupeMode) == DupeModeForce {
+			continue
+		}
+		if group.DupeScore >= score {
+			explanation.Verdict, explanation.Reason = DupeBackup, "a queued item has the same or a higher score"
+			explanation.Replaced = nil
+			return explanation
+		}
+		explanation.Replaced = append(explanation.Replaced, group)
+	}
+	explanation.Verdict = DupeAdd
+	switch {
+	case mode == DupeModeAll && set.Best != nil:
+		explanation.Reason = "ALL mode downloads regardless of successful items"
+	case len(explanation.Replaced) > 0:
+		explanation.Reason = "the item scores higher than the queued items"
+	case set.Best != nil:
+		explanation.Reason = "the item scores higher than the successful items"
+	default:
+		explanation.Reason = "no successful or queued item with the key"
+	}
+	return explanation
+}
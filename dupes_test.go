@@ -0,0 +1,87 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("DupeAnalyzer", func() {
+	groups := []nzbget.FileGroup{
+		{NZBID: 10, NZBName: "Show.S01E01.720p", DupeKey: "show-1", DupeScore: 100, DupeMode: "SCORE"},
+		{NZBID: 11, NZBName: "Show.S01E01.1080p", DupeKey: "show-1", DupeScore: 300, DupeMode: "SCORE"},
+		{NZBID: 12, NZBName: "Show.S01E01.Proper", DupeKey: "show-1", DupeScore: 50, DupeMode: "FORCE"},
+		{NZBID: 13, NZBName: "Show.S01E02", DupeKey: "show-2", DupeScore: 100, DupeMode: "ALL"},
+		{NZBID: 14, NZBName: "Linux.ISO"},
+	}
+	history := []nzbget.HistoricalEntry{
+		{NZBID: 1, Kind: "NZB", DupeKey: "show-1", DupeScore: 200, DupeMode: "SCORE",
+			ParStatus: nzbget.ParStatusSuccess, UnpackStatus: nzbget.UnpackStatusSuccess, HistoryTime: 100},
+		{NZBID: 2, Kind: "DUP", DupeKey: "show-1", DupeScore: 200, DupStatus: nzbget.DupStatusSuccess, HistoryTime: 200},
+		{NZBID: 3, Kind: "NZB", DupeKey: "show-1", DupeScore: 500, DeleteStatus: nzbget.DeleteStatusHealth},
+		{NZBID: 4, Kind: "DUP", DupeKey: "show-2", DupeScore: 10, DupStatus: nzbget.DupStatusGood},
+	}
+	analyzer := nzbget.NewDupeAnalyzer(groups, history)
+
+	It("should group the items by key", func() {
+		sets := analyzer.Sets()
+		Expect(sets).To(HaveLen(2))
+		Expect(sets[0].Key).To(Equal("show-1"))
+		Expect(sets[0].Queued).To(HaveLen(3))
+		Expect(sets[0].History).To(HaveLen(3))
+		_, ok := analyzer.Set("")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should find the best success and the redundant queued items", func() {
+		set, ok := analyzer.Set("show-1")
+		Expect(ok).To(BeTrue())
+		Expect(set.Best.NZBID).To(Equal(2))
+		Expect(set.Good).To(BeFalse())
+		Expect(set.Redundant).To(HaveLen(1))
+		Expect(set.Redundant[0].NZBID).To(Equal(10))
+
+		set, _ = analyzer.Set("show-2")
+		Expect(set.Good).To(BeTrue())
+		Expect(set.Redundant).To(HaveLen(1))
+		Expect(set.Redundant[0].NZBID).To(Equal(13))
+	})
+
+	It("should explain the duplicate check", func() {
+		explanation := analyzer.Explain("show-1", 150, nzbget.DupeModeScore)
+		Expect(explanation.Verdict).To(Equal(nzbget.DupeSkip))
+
+		explanation = analyzer.Explain("show-1", 250, nzbget.DupeModeScore)
+		Expect(explanation.Verdict).To(Equal(nzbget.DupeBackup))
+		Expect(explanation.Replaced).To(BeEmpty())
+
+		explanation = analyzer.Explain("show-1", 400, nzbget.DupeModeScore)
+		Expect(explanation.Verdict).To(Equal(nzbget.DupeAdd))
+		Expect(explanation.Replaced).To(HaveLen(2))
+
+		Expect(analyzer.Explain("show-2", 500, nzbget.DupeModeAll).Verdict).To(Equal(nzbget.DupeSkip))
+		Expect(analyzer.Explain("show-2", 0, nzbget.DupeModeForce).Verdict).To(Equal(nzbget.DupeAdd))
+		Expect(analyzer.Explain("movie", 0, nzbget.DupeModeScore).Verdict).To(Equal(nzbget.DupeAdd))
+	})
+
+	It("should fetch the queue and the hidden history", func() {
+		defer gock.Off()
+		mockGroups(`{"version": "1.1", "result": [{"NZBID": 1, "DupeKey": "show-1", "DupeScore": 10}]}`)
+		gock.New(nzbgetURL).
+			Post("/jsonrpc").
+			JSON(map[string]interface{}{"method": "history", "params": []bool{true}}).
+			Reply(200).
+			JSON(`{"version": "1.1", "result": [{"NZBID": 2, "Kind": "DUP", "DupeKey": "show-1", "DupeScore": 20, "DupStatus": "SUCCESS"}]}`)
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		analyzer, err := client.Dupes()
+		Expect(err).ToNot(HaveOccurred())
+		set, ok := analyzer.Set("show-1")
+		Expect(ok).To(BeTrue())
+		Expect(set.Best.NZBID).To(Equal(2))
+		Expect(set.Redundant).To(HaveLen(1))
+		Expect(gock.IsDone()).To(BeTrue())
+	})
+})
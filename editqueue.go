@@ -0,0 +1,97 @@
+package nzbget
+
+import "errors"
+
+// ErrEditRejected is returned by the queue monitors when the server refuses
+// a change of the queue or its settings.
+var ErrEditRejected = errors.New("nzbget: queue edit was rejected")
+
+// EditCommand is a command of the editqueue API
+type EditCommand string
+
+// Commands of the editqueue API. Group commands act on download queue groups,
+// Post commands on groups in post-processing and History commands on history
+// entries; all take NZBIDs. File commands take file IDs.
+const (
+	EditFileMoveOffset     EditCommand = "FileMoveOffset"
+	EditFileMoveTop        EditCommand = "FileMoveTop"
+	EditFileMoveBottom     EditCommand = "FileMoveBottom"
+	EditFilePause          EditCommand = "FilePause"
+	EditFileResume         EditCommand = "FileResume"
+	EditFileDelete         EditCommand = "FileDelete"
+	EditFilePauseAllPars   EditCommand = "FilePauseAllPars"
+	EditFilePauseExtraPars EditCommand = "FilePauseExtraPars"
+	EditFileReorder        EditCommand = "FileReorder"
+	EditFileSplit          EditCommand = "FileSplit"
+
+	EditGroupMoveOffset     EditCommand = "GroupMoveOffset"
+	EditGroupMoveTop        EditCommand = "GroupMoveTop"
+	EditGroupMoveBottom     EditCommand = "GroupMoveBottom"
+	EditGroupPause          EditCommand = "GroupPause"
+	EditGroupResume         EditCommand = "GroupResume"
+	EditGroupDelete         EditCommand = "GroupDelete"
+	EditGroupParkDelete     EditCommand = "GroupParkDelete"
+	EditGroupDupeDelete     EditCommand = "GroupDupeDelete"
+	EditGroupFinalDelete    EditCommand = "GroupFinalDelete"
+	EditGroupPauseAllPars   EditCommand = "GroupPauseAllPars"
+	EditGroupPauseExtraPars EditCommand = "GroupPauseExtraPars"
+	EditGroupSetPriority    EditCommand = "GroupSetPriority"
+	EditGroupSetCategory    EditCommand = "GroupSetCategory"
+	EditGroupApplyCategory  EditCommand = "GroupApplyCategory"
+	EditGroupMerge          EditCommand = "GroupMerge"
+	EditGroupSetParameter   EditCommand = "GroupSetParameter"
+	EditGroupSetName        EditCommand = "GroupSetName"
+	EditGroupSetDupeKey     EditCommand = "GroupSetDupeKey"
+	EditGroupSetDupeScore   EditCommand = "GroupSetDupeScore"
+	EditGroupSetDupeMode    EditCommand = "GroupSetDupeMode"
+	EditGroupSort           EditCommand = "GroupSort"
+
+	EditPostDelete EditCommand = "PostDelete"
+
+	EditHistoryDelete        EditCommand = "HistoryDelete"
+	EditHistoryFinalDelete   EditCommand = "HistoryFinalDelete"
+	EditHistoryReturn        EditCommand = "HistoryReturn"
+	EditHistoryProcess       EditCommand = "HistoryProcess"
+	EditHistoryRedownload    EditCommand = "HistoryRedownload"
+	EditHistoryRetryFailed   EditCommand = "HistoryRetryFailed"
+	EditHistorySetParameter  EditCommand = "HistorySetParameter"
+	EditHistorySetDupeKey    EditCommand = "HistorySetDupeKey"
+	EditHistorySetDupeScore  EditCommand = "HistorySetDupeScore"
+	EditHistorySetDupeMode   EditCommand = "HistorySetDupeMode"
+	EditHistorySetDupeBackup EditCommand = "HistorySetDupeBackup"
+	EditHistoryMarkBad       EditCommand = "HistoryMarkBad"
+	EditHistoryMarkGood      EditCommand = "HistoryMarkGood"
+	EditHistoryMarkSuccess   EditCommand = "HistoryMarkSuccess"
+)
+
+// EditQueue runs the command with its parameter on the items with the given
+// IDs and reports whether the server accepted it.
+func (n NZBGet) EditQueue(command EditCommand, param string, ids ...int) (bool, error) {
+	if ids == nil {
+		ids = []int{}
+	}
+	var ok bool
+	err := n.call("editqueue", &ok, command, param, ids)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// edit runs EditQueue and returns ErrEditRejected if the server refuses the
+// command.
+func (n NZBGet) edit(command EditCommand, param string, ids ...int) error {
+	return accepted(n.EditQueue(command, param, ids...))
+}
+
+// accepted turns the result of a method reporting success into an error,
+// ErrEditRejected if the server refused.
+func accepted(ok bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrEditRejected
+	}
+	return nil
+}
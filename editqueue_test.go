@@ -0,0 +1,57 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockEdit(command nzbget.EditCommand, param string, ids []int, result bool) {
+	reply := `{"version": "1.1", "result": false}`
+	if result {
+		reply = `{"version": "1.1", "result": true}`
+	}
+	gock.New(nzbgetURL).
+		Post("/jsonrpc").
+		JSON(map[string]interface{}{"method": "editqueue", "params": []interface{}{command, param, ids}}).
+		Reply(200).
+		JSON(reply)
+}
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#EditQueue", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		Context("successful", func() {
+			BeforeEach(func() {
+				mockEdit(nzbget.EditGroupSetPriority, "100", []int{1, 2}, true)
+			})
+
+			It("should send the command", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				ok, err := client.EditQueue(nzbget.EditGroupSetPriority, "100", 1, 2)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ok).To(BeTrue())
+			})
+		})
+
+		Context("without IDs", func() {
+			BeforeEach(func() {
+				mockEdit(nzbget.EditGroupSort, "name", []int{}, false)
+			})
+
+			It("should send an empty list and report the refusal", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				ok, err := client.EditQueue(nzbget.EditGroupSort, "name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+})
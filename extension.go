@@ -0,0 +1,217 @@
+package nzbget
+
+// ExtensionKind is a kind of extension script
+type ExtensionKind string
+
+// Kinds of extension scripts
+const (
+	ExtensionKindPostProcessing ExtensionKind = "POST-PROCESSING"
+	ExtensionKindQueue          ExtensionKind = "QUEUE"
+	ExtensionKindScan           ExtensionKind = "SCAN"
+	ExtensionKindScheduler      ExtensionKind = "SCHEDULER"
+	ExtensionKindFeed           ExtensionKind = "FEED"
+)
+
+// Extension is an extension script installed on the server.
+type Extension struct {
+	// Entry is the path of the main file of the extension.
+	Entry string `json:"Entry"`
+
+	// Location is the directory the extension is installed in.
+	Location string `json:"Location"`
+
+	// RootDir is the extension directory (option ScriptDir) the extension
+	// was found in.
+	RootDir string `json:"RootDir"`
+
+	// Name is the unique name of the extension. It is used as the prefix of
+	// the extension options and post-processing parameters.
+	Name string `json:"Name"`
+
+	// DisplayName is the name of the extension ready for user-friendly
+	// output.
+	DisplayName string `json:"DisplayName"`
+
+	// About is the short description of the extension.
+	About string `json:"About"`
+
+	// Author is the author of the extension.
+	Author string `json:"Author"`
+
+	// Homepage is the URL of the homepage of the extension.
+	Homepage string `json:"Homepage"`
+
+	// License is the license of the extension.
+	License string `json:"License"`
+
+	// Version is the version of the extension.
+	Version string `json:"Version"`
+
+	// NZBGetMinVersion is the minimal version of NZBGet the extension
+	// supports.
+	NZBGetMinVersion string `json:"NZBGetMinVersion"`
+
+	// PostScript is true if the extension is a post-processing script.
+	PostScript bool `json:"PostScript"`
+
+	// ScanScript is true if the extension is a scan script.
+	ScanScript bool `json:"ScanScript"`
+
+	// QueueScript is true if the extension is a queue script.
+	QueueScript bool `json:"QueueScript"`
+
+	// SchedulerScript is true if the extension is a scheduler script.
+	SchedulerScript bool `json:"SchedulerScript"`
+
+	// FeedScript is true if the extension is a feed script.
+	FeedScript bool `json:"FeedScript"`
+
+	// QueueEvents is the list of queue events the queue script is called
+	// for, e.g. “NZB_ADDED, NZB_DOWNLOADED”.
+	QueueEvents string `json:"QueueEvents"`
+
+	// TaskTime is the default schedule of a scheduler script.
+	TaskTime string `json:"TaskTime"`
+
+	// Description is the long description of the extension, one paragraph
+	// per entry.
+	Description []string `json:"Description"`
+
+	// Requirements is the list of requirements of the extension, such as
+	// the needed interpreter version.
+	Requirements []string `json:"Requirements"`
+
+	// Options are the configuration options of the extension.
+	Options []ExtensionOption `json:"Options"`
+
+	// Commands are the commands which can be executed from the settings page
+	// of the extension.
+	Commands []ExtensionCommand `json:"Commands"`
+}
+
+// ExtensionOption is a configuration option of an extension.
+type ExtensionOption struct {
+	// Name is the name of the option without the extension prefix.
+	Name string `json:"Name"`
+
+	// DisplayName is the name of the option ready for user-friendly output.
+	DisplayName string `json:"DisplayName"`
+
+	// Value is the default value of the option.
+	Value string `json:"Value"`
+
+	// Description is the description of the option, one paragraph per entry.
+	Description []string `json:"Description"`
+
+	// Select is the list of allowed values or empty if any value is allowed.
+	Select []string `json:"Select"`
+
+	// Section is the name of the section the option belongs to.
+	Section string `json:"Section"`
+}
+
+// ExtensionCommand is a command an extension provides on its settings page.
+type ExtensionCommand struct {
+	// Name is the name of the command.
+	Name string `json:"Name"`
+
+	// DisplayName is the name of the command ready for user-friendly output.
+	DisplayName string `json:"DisplayName"`
+
+	// Action is the caption of the button executing the command.
+	Action string `json:"Action"`
+
+	// Description is the description of the command, one paragraph per
+	// entry.
+	Description []string `json:"Description"`
+
+	// Section is the name of the section the command belongs to.
+	Section string `json:"Section"`
+}
+
+// Kinds returns the kinds of script the extension implements. An extension
+// can be of several kinds at once.
+func (e Extension) Kinds() []ExtensionKind {
+	var kinds []ExtensionKind
+	if e.PostScript {
+		kinds = append(kinds, ExtensionKindPostProcessing)
+	}
+	if e.QueueScript {
+		kinds = append(kinds, ExtensionKindQueue)
+	}
+	if e.ScanScript {
+		kinds = append(kinds, ExtensionKindScan)
+	}
+	if e.SchedulerScript {
+		kinds = append(kinds, ExtensionKindScheduler)
+	}
+	if e.FeedScript {
+		kinds = append(kinds, ExtensionKindFeed)
+	}
+	return kinds
+}
+
+// Is reports whether the extension implements the given kind of script.
+func (e Extension) Is(kind ExtensionKind) bool {
+	for _, k := range e.Kinds() {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Extensions returns the installed extensions. If loadFromDisk is true the
+// server rescans the extension directories first, otherwise the list loaded
+// on startup is returned.
+func (n NZBGet) Extensions(loadFromDisk bool) ([]Extension, error) {
+	var extensions []Extension
+	err := n.call("loadextensions", &extensions, loadFromDisk)
+	if err != nil {
+		return nil, err
+	}
+	return extensions, nil
+}
+
+// DownloadExtension downloads the extension archive from the given URL and
+// installs it under the given name
+func (n NZBGet) DownloadExtension(url, name string) (bool, error) {
+	var ok bool
+	err := n.call("downloadextension", &ok, url, name)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// UpdateExtension replaces the installed extension with the given name by the
+// archive downloaded from the given URL
+func (n NZBGet) UpdateExtension(url, name string) (bool, error) {
+	var ok bool
+	err := n.call("updateextension", &ok, url, name)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// DeleteExtension removes the installed extension with the given name
+func (n NZBGet) DeleteExtension(name string) (bool, error) {
+	var ok bool
+	err := n.call("deleteextension", &ok, name)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// TestExtension checks that the extension with the given entry file can be
+// executed on the server, i.e. its interpreter is available
+func (n NZBGet) TestExtension(entry string) (bool, error) {
+	var ok bool
+	err := n.call("testextension", &ok, entry)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
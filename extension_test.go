@@ -0,0 +1,159 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const extensions = `{
+  "version": "1.1",
+  "result": [
+    {
+      "Entry": "\/srv\/ppscripts\/VideoSort\/main.py",
+      "Location": "\/srv\/ppscripts\/VideoSort",
+      "RootDir": "\/srv\/ppscripts",
+      "Name": "VideoSort",
+      "DisplayName": "VideoSort",
+      "About": "Sorts movies and tv shows.",
+      "Author": "Andrey Prygunkov",
+      "Homepage": "https:\/\/github.com\/nzbgetcom\/Extension-VideoSort",
+      "License": "GNU",
+      "Version": "10.0",
+      "NZBGetMinVersion": "23.0",
+      "PostScript": true,
+      "ScanScript": false,
+      "QueueScript": false,
+      "SchedulerScript": false,
+      "FeedScript": false,
+      "QueueEvents": "",
+      "TaskTime": "",
+      "Description": ["This is a script for sorting video files."],
+      "Requirements": ["Python 3.8 or later"],
+      "Options": [
+        {
+          "Name": "MoviesFormat",
+          "DisplayName": "MoviesFormat",
+          "Value": "%t (%y)",
+          "Description": ["Formatting rules for movies."],
+          "Select": [],
+          "Section": ""
+        }
+      ],
+      "Commands": []
+    },
+    {
+      "Entry": "\/srv\/ppscripts\/Completion\/main.py",
+      "Location": "\/srv\/ppscripts\/Completion",
+      "RootDir": "\/srv\/ppscripts",
+      "Name": "Completion",
+      "DisplayName": "Completion",
+      "Version": "3.0",
+      "PostScript": false,
+      "ScanScript": true,
+      "QueueScript": true,
+      "SchedulerScript": true,
+      "FeedScript": false,
+      "QueueEvents": "NZB_ADDED, NZB_DOWNLOADED",
+      "TaskTime": "*;*:00",
+      "Options": [],
+      "Commands": [
+        {
+          "Name": "ConnectionTest",
+          "DisplayName": "ConnectionTest",
+          "Action": "Test Connection",
+          "Description": ["Test the connection to the indexer."],
+          "Section": ""
+        }
+      ]
+    }
+  ]
+}`
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#Extensions", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "loadextensions", "params": []bool{true}}).
+					Reply(200).
+					JSON(extensions)
+			})
+
+			It("should return the installed extensions", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				extensions, err := client.Extensions(true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(extensions)).To(Equal(2))
+				Expect(extensions[0].Version).To(Equal("10.0"))
+				Expect(extensions[0].Kinds()).To(Equal([]nzbget.ExtensionKind{nzbget.ExtensionKindPostProcessing}))
+				Expect(extensions[0].Options[0].Value).To(Equal("%t (%y)"))
+				Expect(extensions[1].Kinds()).To(Equal([]nzbget.ExtensionKind{
+					nzbget.ExtensionKindQueue,
+					nzbget.ExtensionKindScan,
+					nzbget.ExtensionKindScheduler,
+				}))
+				Expect(extensions[1].Is(nzbget.ExtensionKindFeed)).To(BeFalse())
+				Expect(extensions[1].Commands[0].Action).To(Equal("Test Connection"))
+			})
+		})
+	})
+
+	Context("#DownloadExtension", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{
+						"method": "downloadextension",
+						"params": []string{"https://example.com/VideoSort.zip", "VideoSort"},
+					}).
+					Reply(200).
+					JSON(`{"version": "1.1", "result": true}`)
+			})
+
+			It("should install the extension", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				ok, err := client.DownloadExtension("https://example.com/VideoSort.zip", "VideoSort")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ok).To(BeTrue())
+			})
+		})
+	})
+
+	Context("#DeleteExtension", func() {
+		Context("failed", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "deleteextension", "params": []string{"Missing"}}).
+					Reply(200).
+					JSON(`{"version": "1.1", "error": {"name": "JSONRPCError", "code": 3, "message": "Extension not found"}}`)
+			})
+
+			It("should return the server error", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.DeleteExtension("Missing")
+				Expect(err).To(BeAssignableToTypeOf(&nzbget.RPCError{}))
+			})
+		})
+	})
+})
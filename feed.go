@@ -0,0 +1,173 @@
+package nzbget
+
+// FeedMatch is the result of matching a feed item against the feed filter
+type FeedMatch string
+
+// Results of the feed filter
+const (
+	FeedMatchIgnored  FeedMatch = "IGNORED"
+	FeedMatchAccepted FeedMatch = "ACCEPTED"
+	FeedMatchRejected FeedMatch = "REJECTED"
+)
+
+// FeedItemStatus is the processing state of a feed item
+type FeedItemStatus string
+
+// Processing states of feed items
+const (
+	FeedItemStatusUnknown FeedItemStatus = "UNKNOWN"
+	FeedItemStatusBacklog FeedItemStatus = "BACKLOG"
+	FeedItemStatusFetched FeedItemStatus = "FETCHED"
+	FeedItemStatusNew     FeedItemStatus = "NEW"
+)
+
+// FeedItem is an item of an RSS feed together with the result of the feed
+// filter.
+type FeedItem struct {
+	// Title is the title of the item as published in the feed.
+	Title string `json:"Title"`
+
+	// Filename is the name of the nzb-file the item would be added as.
+	Filename string `json:"Filename"`
+
+	// URL is the URL of the nzb-file.
+	URL string `json:"URL"`
+
+	// SizeLo is the size of the item in bytes, Low 32-bits of 64-bit value.
+	SizeLo int `json:"SizeLo"`
+
+	// SizeHi is the size of the item in bytes, High 32-bits of 64-bit value.
+	SizeHi int `json:"SizeHi"`
+
+	// SizeMB is the size of the item in megabytes.
+	SizeMB int `json:"SizeMB"`
+
+	// Category is the category of the item as published in the feed.
+	Category string `json:"Category"`
+
+	// AddCategory is the category the item would be added with, as set by
+	// the filter or the feed options.
+	AddCategory string `json:"AddCategory"`
+
+	// Time is the date/time when the item was published (Time is in C/Unix
+	// format).
+	Time int `json:"Time"`
+
+	// Match is the result of the feed filter:
+	//
+	//    IGNORED - no rule of the filter has matched;
+	//    ACCEPTED - the item was accepted by rule Rule;
+	//    REJECTED - the item was rejected by rule Rule.
+	Match FeedMatch `json:"Match"`
+
+	// Rule is the number of the filter rule which has accepted or rejected
+	// the item.
+	Rule int `json:"Rule"`
+
+	// DupeKey is the duplicate key assigned by the filter.
+	DupeKey string `json:"DupeKey"`
+
+	// DupeScore is the duplicate score assigned by the filter.
+	DupeScore int `json:"DupeScore"`
+
+	// DupeMode is the duplicate mode. One of SCORE, ALL, FORCE.
+	DupeMode string `json:"DupeMode"`
+
+	// Status is the processing state of the item:
+	//
+	//    UNKNOWN - the state could not be determined (previews);
+	//    BACKLOG - the item was published before the feed was added and was
+	//    			ignored because backlog is disabled;
+	//    FETCHED - the item was already added to the queue;
+	//    NEW - the item is new and was not processed yet.
+	Status FeedItemStatus `json:"Status"`
+}
+
+// FeedPreview is the definition of a feed to preview. The fields correspond to
+// the options of section “FeedN” of the configuration file.
+type FeedPreview struct {
+	// ID is the number of an existing feed whose cached content should be
+	// used, or 0 to load the feed from URL.
+	ID int
+
+	// Name is the name of the feed.
+	Name string
+
+	// URL is the address of the feed.
+	URL string
+
+	// Filter is the filter to test against the feed items.
+	Filter string
+
+	// Backlog indicates if items published before the feed was added are
+	// processed.
+	Backlog bool
+
+	// PauseNZB indicates if accepted items are added paused.
+	PauseNZB bool
+
+	// Category is the category accepted items are added with.
+	Category string
+
+	// Priority is the priority accepted items are added with.
+	Priority int
+
+	// Interval is the interval of feed checks, in minutes.
+	Interval int
+
+	// FeedScript is the list of feed scripts to run on the feed content.
+	FeedScript string
+
+	// CacheTimeSec is the time the loaded feed is kept in cache, in seconds.
+	CacheTimeSec int
+
+	// CacheID is the key of the cache entry. Previews using the same key
+	// reuse the loaded feed content.
+	CacheID string
+}
+
+// ViewFeed returns the items of the feed with the given ID together with the
+// result of its filter
+func (n NZBGet) ViewFeed(id int) ([]FeedItem, error) {
+	var items []FeedItem
+	err := n.call("viewfeed", &items, id)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// PreviewFeed loads the given feed and returns its items with the result of
+// the filter, without adding anything to the queue. It is useful to test
+// filters before they are saved into the configuration.
+func (n NZBGet) PreviewFeed(feed FeedPreview) ([]FeedItem, error) {
+	var items []FeedItem
+	err := n.call("previewfeed", &items,
+		feed.ID,
+		feed.Name,
+		feed.URL,
+		feed.Filter,
+		feed.Backlog,
+		feed.PauseNZB,
+		feed.Category,
+		feed.Priority,
+		feed.Interval,
+		feed.FeedScript,
+		feed.CacheTimeSec,
+		feed.CacheID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FetchFeed requests the immediate fetch of the feed with the given ID
+func (n NZBGet) FetchFeed(id int) (bool, error) {
+	var fetched bool
+	err := n.call("fetchfeed", &fetched, id)
+	if err != nil {
+		return false, err
+	}
+	return fetched, nil
+}
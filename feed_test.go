@@ -0,0 +1,143 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const feedItems = `{
+  "version": "1.1",
+  "result": [
+    {
+      "Title": "My.Show.S01E01.720p",
+      "Filename": "My.Show.S01E01.720p",
+      "URL": "https:\/\/indexer.example\/getnzb\/1.nzb",
+      "SizeLo": 1073741824,
+      "SizeHi": 0,
+      "SizeMB": 1024,
+      "Category": "TV > HD",
+      "AddCategory": "TV",
+      "Time": 1589707990,
+      "Match": "ACCEPTED",
+      "Rule": 2,
+      "DupeKey": "tvdb=1234-S01E01",
+      "DupeScore": 100,
+      "DupeMode": "SCORE",
+      "Status": "FETCHED"
+    },
+    {
+      "Title": "My.Show.S01E01.480p",
+      "Filename": "My.Show.S01E01.480p",
+      "URL": "https:\/\/indexer.example\/getnzb\/2.nzb",
+      "SizeLo": 314572800,
+      "SizeHi": 0,
+      "SizeMB": 300,
+      "Category": "TV > SD",
+      "AddCategory": "",
+      "Time": 1589707900,
+      "Match": "REJECTED",
+      "Rule": 1,
+      "DupeKey": "",
+      "DupeScore": 0,
+      "DupeMode": "SCORE",
+      "Status": "NEW"
+    }
+  ]
+}`
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#ViewFeed", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "viewfeed", "params": []int{1}}).
+					Reply(200).
+					JSON(feedItems)
+			})
+
+			It("should return the feed items", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				items, err := client.ViewFeed(1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(items)).To(Equal(2))
+				Expect(items[0]).To(MatchFields(IgnoreExtras, Fields{
+					"Title":       Equal("My.Show.S01E01.720p"),
+					"AddCategory": Equal("TV"),
+					"Match":       Equal(nzbget.FeedMatchAccepted),
+					"Rule":        Equal(2),
+					"DupeScore":   Equal(100),
+					"Status":      Equal(nzbget.FeedItemStatusFetched),
+				}))
+				Expect(items[1].Match).To(Equal(nzbget.FeedMatchRejected))
+			})
+		})
+	})
+
+	Context("#PreviewFeed", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{
+						"method": "previewfeed",
+						"params": []interface{}{0, "Indexer", "https://indexer.example/rss", "A: 720p", false, false, "TV", 0, 15, "", 0, "preview-1"},
+					}).
+					Reply(200).
+					JSON(feedItems)
+			})
+
+			It("should return the feed items with the filter applied", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				items, err := client.PreviewFeed(nzbget.FeedPreview{
+					Name:     "Indexer",
+					URL:      "https://indexer.example/rss",
+					Filter:   "A: 720p",
+					Category: "TV",
+					Interval: 15,
+					CacheID:  "preview-1",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(items)).To(Equal(2))
+			})
+		})
+	})
+
+	Context("#FetchFeed", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "fetchfeed", "params": []int{1}}).
+					Reply(200).
+					JSON(`{"version": "1.1", "result": true}`)
+			})
+
+			It("should request the fetch", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				fetched, err := client.FetchFeed(1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fetched).To(BeTrue())
+			})
+		})
+	})
+})
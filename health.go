@@ -0,0 +1,270 @@
+package nzbget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// HealthLevel rates the health of a group relative to its critical health
+type HealthLevel int
+
+// Levels of health
+const (
+	// HealthOK means the health is well above the critical health.
+	HealthOK HealthLevel = iota
+
+	// HealthWarning means the health is within the margin above the critical
+	// health.
+	HealthWarning
+
+	// HealthCritical means the health is below the critical health; the
+	// download cannot be repaired anymore.
+	HealthCritical
+)
+
+func (l HealthLevel) String() string {
+	switch l {
+	case HealthOK:
+		return "OK"
+	case HealthWarning:
+		return "WARNING"
+	case HealthCritical:
+		return "CRITICAL"
+	}
+	return "HealthLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// HealthAction is what a HealthMonitor does with a group reaching a level
+type HealthAction string
+
+// Actions of a HealthMonitor
+const (
+	// HealthActionAlert only records the decision.
+	HealthActionAlert HealthAction = "ALERT"
+
+	// HealthActionPause pauses the group.
+	HealthActionPause HealthAction = "PAUSE"
+
+	// HealthActionPriority sets the priority of the group, by default to
+	// PriorityVeryLow.
+	HealthActionPriority HealthAction = "PRIORITY"
+
+	// HealthActionDelete deletes the group, moving it to history.
+	HealthActionDelete HealthAction = "DELETE"
+)
+
+// HealthDecision is an entry of the decision log of a HealthMonitor.
+type HealthDecision struct {
+	// Time is the time of the decision.
+	Time time.Time
+
+	// NZBID and Name identify the group.
+	NZBID int
+	Name  string
+
+	// Health and CriticalHealth are the health values of the group, in
+	// permille.
+	Health, CriticalHealth int
+
+	// Level is the level the group has reached.
+	Level HealthLevel
+
+	// Action is the action decided on.
+	Action HealthAction
+
+	// DryRun is set if the action was not carried out because of dry-run
+	// mode.
+	DryRun bool
+
+	// Err is the error returned by the server when carrying out the action.
+	Err error
+}
+
+func (d HealthDecision) String() string {
+	result := "done"
+	switch {
+	case d.DryRun:
+		result = "dry run"
+	case d.Err != nil:
+		result = "failed: " + d.Err.Error()
+	}
+	return fmt.Sprintf("%s #%d %q health %.1f%% critical %.1f%%: %s (%s)",
+		d.Level, d.NZBID, d.Name, float64(d.Health)/10, float64(d.CriticalHealth)/10, d.Action, result)
+}
+
+// HealthOption configures a HealthMonitor
+type HealthOption func(*HealthMonitor)
+
+// WithHealthInterval sets how often the queue is checked. The default is ten
+// seconds.
+func WithHealthInterval(interval time.Duration) HealthOption {
+	return func(m *HealthMonitor) {
+		m.interval = interval
+	}
+}
+
+// WithHealthMargin sets how close to the critical health, in permille, the
+// health of a group has to get to reach HealthWarning. The default is 50,
+// which is 5%.
+func WithHealthMargin(margin int) HealthOption {
+	return func(m *HealthMonitor) {
+		m.margin = margin
+	}
+}
+
+// WithHealthAction sets the action taken for groups reaching the level. By
+// default both levels only raise an alert.
+func WithHealthAction(level HealthLevel, action HealthAction) HealthOption {
+	return func(m *HealthMonitor) {
+		m.actions[level] = action
+	}
+}
+
+// WithHealthPriority sets the priority used by HealthActionPriority.
+func WithHealthPriority(priority int) HealthOption {
+	return func(m *HealthMonitor) {
+		m.priority = priority
+	}
+}
+
+// WithHealthLog makes the monitor write every decision to w, one per line,
+// in the order they are made.
+func WithHealthLog(w io.Writer) HealthOption {
+	return func(m *HealthMonitor) {
+		m.log = w
+	}
+}
+
+// WithHealthDryRun makes the monitor record its decisions without carrying
+// them out.
+func WithHealthDryRun(dryRun bool) HealthOption {
+	return func(m *HealthMonitor) {
+		m.dryRun = dryRun
+	}
+}
+
+// HealthMonitor watches the health of the groups being downloaded. Each time
+// a group reaches a worse level it decides on the configured action, writes
+// the decision to the decision log and sends it on Decisions. Failed actions are decided on again on the
+// next check. Groups in post-processing are not checked, their health no
+// longer matters.
+type HealthMonitor struct {
+	client    *NZBGet
+	interval  time.Duration
+	margin    int
+	priority  int
+	dryRun    bool
+	log       io.Writer
+	actions   map[HealthLevel]HealthAction
+	decisions chan HealthDecision
+	levels    map[int]HealthLevel
+}
+
+// NewHealthMonitor returns a health monitor for the given client.
+func NewHealthMonitor(client *NZBGet, opts ...HealthOption) *HealthMonitor {
+	m := &HealthMonitor{
+		client:   client,
+		interval: 10 * time.Second,
+		margin:   50,
+		priority: PriorityVeryLow,
+		actions: map[HealthLevel]HealthAction{
+			HealthWarning:  HealthActionAlert,
+			HealthCritical: HealthActionAlert,
+		},
+		decisions: make(chan HealthDecision, 100),
+		levels:    map[int]HealthLevel{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Decisions returns the channel the decisions are sent on. It is closed when
+// Run returns.
+func (m *HealthMonitor) Decisions() <-chan HealthDecision {
+	return m.decisions
+}
+
+// Level returns the health level of the group. A group without failed
+// articles is always OK, even if it has no par-files and its critical health
+// is therefore 100%.
+func (m *HealthMonitor) Level(group FileGroup) HealthLevel {
+	switch {
+	case group.Health >= 1000:
+		return HealthOK
+	case group.Health < group.CriticalHealth:
+		return HealthCritical
+	case group.Health < group.CriticalHealth+m.margin:
+		return HealthWarning
+	}
+	return HealthOK
+}
+
+// Run checks the health of the queue until the context is done and returns
+// the context error. Rounds in which the queue cannot be fetched are skipped.
+func (m *HealthMonitor) Run(ctx context.Context) error {
+	defer close(m.decisions)
+	return pollEvery(ctx, m.interval, func() {
+		if groups, err := m.client.FileGroups(); err == nil {
+			m.check(groups)
+		}
+	})
+}
+
+// check decides on the groups which got worse since the last check.
+func (m *HealthMonitor) check(groups []FileGroup) {
+	levels := make(map[int]HealthLevel, len(groups))
+	for _, group := range groups {
+		if group.Status.IsPostProcessing() || group.Status.IsTerminal() {
+			continue
+		}
+		level := m.Level(group)
+		previous := m.levels[group.NZBID]
+		levels[group.NZBID] = level
+		if level <= previous {
+			continue
+		}
+
+		decision := HealthDecision{
+			Time:           time.Now(),
+			NZBID:          group.NZBID,
+			Name:           group.NZBName,
+			Health:         group.Health,
+			CriticalHealth: group.CriticalHealth,
+			Level:          level,
+			Action:         m.actions[level],
+			DryRun:         m.dryRun,
+		}
+		if !m.dryRun {
+			decision.Err = m.apply(decision.Action, group.NZBID)
+		}
+		if decision.Err != nil {
+			// Decide again on the next check.
+			levels[group.NZBID] = previous
+		}
+		if m.log != nil {
+			fmt.Fprintln(m.log, decision)
+		}
+		select {
+		case m.decisions <- decision:
+		default:
+		}
+	}
+	m.levels = levels
+}
+
+func (m *HealthMonitor) apply(action HealthAction, nzbID int) error {
+	switch action {
+	case HealthActionPause:
+		return m.client.edit(EditGroupPause, "", nzbID)
+	case HealthActionPriority:
+		return m.client.edit(EditGroupSetPriority, strconv.Itoa(m.priority), nzbID)
+	case HealthActionDelete:
+		return m.client.edit(EditGroupDelete, "", nzbID)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package nzbget_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const (
+	healthyGroups = `{"version": "1.1", "result": [
+  {"NZBID": 1, "NZBName": "My_File_1", "Status": "DOWNLOADING", "Health": 1000, "CriticalHealth": 1000},
+  {"NZBID": 2, "NZBName": "My_File_2", "Status": "DOWNLOADING", "Health": 990, "CriticalHealth": 900},
+  {"NZBID": 3, "NZBName": "My_File_3", "Status": "UNPACKING", "Health": 800, "CriticalHealth": 900}
+]}`
+	sickGroups = `{"version": "1.1", "result": [
+  {"NZBID": 1, "NZBName": "My_File_1", "Status": "DOWNLOADING", "Health": 1000, "CriticalHealth": 1000},
+  {"NZBID": 2, "NZBName": "My_File_2", "Status": "DOWNLOADING", "Health": 930, "CriticalHealth": 900},
+  {"NZBID": 3, "NZBName": "My_File_3", "Status": "UNPACKING", "Health": 800, "CriticalHealth": 900}
+]}`
+	deadGroups = `{"version": "1.1", "result": [
+  {"NZBID": 2, "NZBName": "My_File_2", "Status": "PAUSED", "Health": 880, "CriticalHealth": 900}
+]}`
+)
+
+func mockGroups(groups string) {
+	gock.New(nzbgetURL).Get("/jsonrpc/listgroups").Reply(200).JSON(groups)
+}
+
+var _ = Describe("HealthMonitor", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	AfterEach(func() {
+		cancel()
+		if done != nil {
+			Eventually(done).Should(Receive())
+		}
+		gock.Off()
+	})
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		done = nil
+		mockGroups(healthyGroups)
+		mockGroups(sickGroups)
+		mockGroups(sickGroups)
+		mockGroups(deadGroups)
+	})
+
+	It("should rate the health", func() {
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		monitor := nzbget.NewHealthMonitor(client)
+		Expect(monitor.Level(nzbget.FileGroup{Health: 1000, CriticalHealth: 1000})).To(Equal(nzbget.HealthOK))
+		Expect(monitor.Level(nzbget.FileGroup{Health: 950, CriticalHealth: 900})).To(Equal(nzbget.HealthOK))
+		Expect(monitor.Level(nzbget.FileGroup{Health: 949, CriticalHealth: 900})).To(Equal(nzbget.HealthWarning))
+		Expect(monitor.Level(nzbget.FileGroup{Health: 899, CriticalHealth: 900})).To(Equal(nzbget.HealthCritical))
+	})
+
+	It("should carry out the actions once per level", func() {
+		mockEdit(nzbget.EditGroupPause, "", []int{2}, true)
+		mockEdit(nzbget.EditGroupDelete, "", []int{2}, false)
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		monitor := nzbget.NewHealthMonitor(client,
+			nzbget.WithHealthInterval(time.Millisecond),
+			nzbget.WithHealthAction(nzbget.HealthWarning, nzbget.HealthActionPause),
+			nzbget.WithHealthAction(nzbget.HealthCritical, nzbget.HealthActionDelete))
+		done = start(ctx, monitor)
+
+		decisions := receive(monitor.Decisions(), 2).([]nzbget.HealthDecision)
+		Expect(decisions[0]).To(MatchFields(IgnoreExtras, Fields{
+			"NZBID":  Equal(2),
+			"Level":  Equal(nzbget.HealthWarning),
+			"Action": Equal(nzbget.HealthActionPause),
+			"Err":    BeNil(),
+		}))
+		Expect(decisions[1]).To(MatchFields(IgnoreExtras, Fields{
+			"NZBID":  Equal(2),
+			"Level":  Equal(nzbget.HealthCritical),
+			"Action": Equal(nzbget.HealthActionDelete),
+			"Err":    Equal(nzbget.ErrEditRejected),
+		}))
+		Expect(decisions[1].String()).To(Equal(`CRITICAL #2 "My_File_2" health 88.0% critical 90.0%: DELETE (failed: nzbget: queue edit was rejected)`))
+	})
+
+	It("should only record decisions in dry-run mode", func() {
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		monitor := nzbget.NewHealthMonitor(client,
+			nzbget.WithHealthInterval(time.Millisecond),
+			nzbget.WithHealthDryRun(true),
+			nzbget.WithHealthAction(nzbget.HealthWarning, nzbget.HealthActionPriority))
+		done = start(ctx, monitor)
+
+		decisions := receive(monitor.Decisions(), 2).([]nzbget.HealthDecision)
+		Expect(decisions[0].Action).To(Equal(nzbget.HealthActionPriority))
+		Expect(decisions[0].DryRun).To(BeTrue())
+		Expect(decisions[0].String()).To(HaveSuffix("PRIORITY (dry run)"))
+		Expect(decisions[1].Action).To(Equal(nzbget.HealthActionAlert))
+	})
+
+	It("should write every decision to the log", func() {
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		log := gbytes.NewBuffer()
+		monitor := nzbget.NewHealthMonitor(client,
+			nzbget.WithHealthInterval(time.Millisecond),
+			nzbget.WithHealthDryRun(true),
+			nzbget.WithHealthLog(log))
+		done = start(ctx, monitor)
+
+		Eventually(log).Should(gbytes.Say(`WARNING #2 "My_File_2" .*: ALERT \(dry run\)\n`))
+		Eventually(log).Should(gbytes.Say(`CRITICAL #2 "My_File_2" .*: ALERT \(dry run\)\n`))
+	})
+})
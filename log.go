@@ -0,0 +1,171 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// LogKind is the kind of a log message
+type LogKind string
+
+// Kinds of log messages
+const (
+	LogKindInfo    LogKind = "INFO"
+	LogKindWarning LogKind = "WARNING"
+	LogKindError   LogKind = "ERROR"
+	LogKindDetail  LogKind = "DETAIL"
+	LogKindDebug   LogKind = "DEBUG"
+)
+
+// LogMessage is a single message of a server log.
+type LogMessage struct {
+	// ID is the ID of the log message. IDs are increasing while the server
+	// runs and start over after a restart.
+	ID int `json:"ID"`
+
+	// Kind is the class of the message: INFO, WARNING, ERROR, DETAIL or DEBUG.
+	Kind LogKind `json:"Kind"`
+
+	// Time is the time when the message was logged (Time is in C/Unix format).
+	Time int `json:"Time"`
+
+	// Text is the text of the message.
+	Text string `json:"Text"`
+}
+
+// Log returns the messages of the server log starting with the message
+// idFrom. If idFrom is 0 the last count messages are returned.
+func (n NZBGet) Log(idFrom, count int) ([]LogMessage, error) {
+	var messages []LogMessage
+	err := n.call("log", &messages, idFrom, count)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// LogOption configures FollowLog
+type LogOption func(*logOptions)
+
+type logOptions struct {
+	interval time.Duration
+	backlog  int
+	kinds    []LogKind
+}
+
+// WithLogInterval sets how often the log is polled. The default is one
+// second.
+func WithLogInterval(interval time.Duration) LogOption {
+	return func(o *logOptions) {
+		o.interval = interval
+	}
+}
+
+// WithLogBacklog makes FollowLog start with the last count messages already
+// in the log. By default only messages logged after the start are delivered.
+func WithLogBacklog(count int) LogOption {
+	return func(o *logOptions) {
+		o.backlog = count
+	}
+}
+
+// WithLogKinds restricts the messages delivered by FollowLog to the given
+// kinds.
+func WithLogKinds(kinds ...LogKind) LogOption {
+	return func(o *logOptions) {
+		o.kinds = kinds
+	}
+}
+
+func (o logOptions) accepts(message LogMessage) bool {
+	if len(o.kinds) == 0 {
+		return true
+	}
+	for _, kind := range o.kinds {
+		if message.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// FollowLog tails the server log and delivers new messages in order on the
+// returned channel, which is closed when the context is done.
+//
+// Message IDs start over when the server restarts. A restart is detected by
+// Status reporting a lower uptime or a different start time, or by the log
+// returning IDs already seen, after which the log is followed from its first
+// message again. Errors while polling are ignored so the
+// follower survives restarts of the server.
+func (n NZBGet) FollowLog(ctx context.Context, opts ...LogOption) <-chan LogMessage {
+	options := logOptions{interval: time.Second}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	messages := make(chan LogMessage, 100)
+	go n.followLog(ctx, options, messages)
+	return messages
+}
+
+func (n NZBGet) followLog(ctx context.Context, options logOptions, messages chan<- LogMessage) {
+	defer close(messages)
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+
+	started := false
+	lastID := 0
+	var last Status
+	for {
+		var batch []LogMessage
+		status, err := n.Status()
+		if err == nil && !started {
+			count := options.backlog
+			if count == 0 {
+				count = 1
+			}
+			batch, err = n.Log(0, count)
+			if err == nil {
+				started = true
+				last = *status
+				if options.backlog == 0 {
+					// Only remember where the log currently ends.
+					for _, message := range batch {
+						lastID = message.ID
+					}
+					batch = nil
+				}
+			}
+		} else if err == nil {
+			if restarted(last, *status) {
+				lastID = 0
+			}
+			last = *status
+			batch, _ = n.Log(lastID+1, 0)
+			if len(batch) > 0 && batch[0].ID <= lastID {
+				// The log started over without the restart being noticed.
+				lastID = 0
+			}
+		}
+
+		for _, message := range batch {
+			if message.ID <= lastID {
+				continue
+			}
+			lastID = message.ID
+			if !options.accepts(message) {
+				continue
+			}
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
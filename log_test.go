@@ -0,0 +1,160 @@
+package nzbget_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockLog(idFrom, count int, result string) {
+	gock.New(nzbgetURL).
+		Post("/jsonrpc").
+		JSON(map[string]interface{}{"method": "log", "params": []int{idFrom, count}}).
+		Reply(200).
+		JSON(`{"version": "1.1", "result": ` + result + `}`)
+}
+
+func mockStatus(upTime string) {
+	gock.New(nzbgetURL).
+		Get("/jsonrpc/status").
+		Reply(200).
+		JSON(statusWithUpTime(upTime))
+}
+
+func messageIDs(messages []nzbget.LogMessage) []int {
+	ids := make([]int, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	return ids
+}
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#Log", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			mockLog(0, 2, `[{"ID": 4, "Kind": "INFO", "Time": 1589707990, "Text": "Queue saved"},
+				{"ID": 5, "Kind": "ERROR", "Time": 1589707995, "Text": "Disk full"}]`)
+		})
+
+		It("should return the last messages", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			messages, err := client.Log(0, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(messageIDs(messages)).To(Equal([]int{4, 5}))
+			Expect(messages[1].Kind).To(Equal(nzbget.LogKindError))
+			Expect(messages[1].Text).To(Equal("Disk full"))
+		})
+	})
+
+	Context("#FollowLog", func() {
+		var (
+			ctx      context.Context
+			cancel   context.CancelFunc
+			messages <-chan nzbget.LogMessage
+		)
+
+		AfterEach(func() {
+			cancel()
+			for range messages {
+			}
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			mockStatus("100")
+			mockLog(0, 1, `[{"ID": 5, "Kind": "INFO", "Text": "Old"}]`)
+			mockStatus("101")
+			mockLog(6, 0, `[{"ID": 6, "Kind": "INFO", "Text": "New"}, {"ID": 7, "Kind": "ERROR", "Text": "Failed"}]`)
+			gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(500)
+			mockStatus("2")
+			mockLog(1, 0, `[{"ID": 1, "Kind": "INFO", "Text": "Started"}, {"ID": 2, "Kind": "WARNING", "Text": "Slow"}]`)
+		})
+
+		It("should follow the log across restarts", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			messages = client.FollowLog(ctx, nzbget.WithLogInterval(time.Millisecond))
+			Expect(messageIDs(receive(messages, 4).([]nzbget.LogMessage))).To(Equal([]int{6, 7, 1, 2}))
+			Consistently(messages, 20*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("should only deliver the selected kinds", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			messages = client.FollowLog(ctx,
+				nzbget.WithLogInterval(time.Millisecond),
+				nzbget.WithLogKinds(nzbget.LogKindError, nzbget.LogKindWarning))
+			received := receive(messages, 2).([]nzbget.LogMessage)
+			Expect(received[0].Text).To(Equal("Failed"))
+			Expect(received[1].Text).To(Equal("Slow"))
+		})
+
+		It("should close the channel when done", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			messages = client.FollowLog(ctx, nzbget.WithLogInterval(time.Millisecond))
+			cancel()
+			Eventually(messages).Should(BeClosed())
+		})
+	})
+
+	Context("#FollowLog across a restart between polls", func() {
+		var (
+			ctx      context.Context
+			cancel   context.CancelFunc
+			messages <-chan nzbget.LogMessage
+		)
+
+		AfterEach(func() {
+			cancel()
+			for range messages {
+			}
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+		})
+
+		It("should notice the start time moving", func() {
+			gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithServerTime("1000", "100"))
+			mockLog(0, 1, `[{"ID": 5, "Kind": "INFO", "Text": "Old"}]`)
+			gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithServerTime("1001", "101"))
+			mockLog(6, 0, `[{"ID": 6, "Kind": "INFO", "Text": "New"}]`)
+			gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithServerTime("2000", "500"))
+			mockLog(1, 0, `[{"ID": 1, "Kind": "INFO", "Text": "Started"}, {"ID": 2, "Kind": "INFO", "Text": "Ready"}]`)
+
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			messages = client.FollowLog(ctx, nzbget.WithLogInterval(time.Millisecond))
+			Expect(messageIDs(receive(messages, 3).([]nzbget.LogMessage))).To(Equal([]int{6, 1, 2}))
+		})
+
+		It("should start over when the log returns IDs already seen", func() {
+			mockStatus("100")
+			mockLog(0, 1, `[{"ID": 5, "Kind": "INFO", "Text": "Old"}]`)
+			mockStatus("101")
+			mockLog(6, 0, `[{"ID": 6, "Kind": "INFO", "Text": "New"}]`)
+			mockStatus("500")
+			mockLog(7, 0, `[{"ID": 1, "Kind": "INFO", "Text": "Started"}, {"ID": 2, "Kind": "INFO", "Text": "Ready"}]`)
+			mockStatus("501")
+			mockLog(3, 0, `[{"ID": 3, "Kind": "INFO", "Text": "Running"}]`)
+
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			messages = client.FollowLog(ctx, nzbget.WithLogInterval(time.Millisecond))
+			Expect(messageIDs(receive(messages, 4).([]nzbget.LogMessage))).To(Equal([]int{6, 1, 2, 3}))
+		})
+	})
+})
@@ -0,0 +1,157 @@
+package nzbget
+
+// Responses of different server versions are normalized into one canonical
+// shape when decoded: fields introduced in newer versions are filled from
+// their deprecated predecessors and vice versa, and statuses missing in older
+// versions are set to NONE.
+
+// UnmarshalJSON decodes a group, keeping unknown fields in Extra, and
+// normalizes deprecated fields.
+func (f *FileGroup) UnmarshalJSON(data []byte) error {
+	type fileGroup FileGroup
+	extra, decoded, err := decodeFields(data, (*fileGroup)(f))
+	if err != nil {
+		return err
+	}
+	f.Extra = extra
+	f.normalize(decoded)
+	return nil
+}
+
+// normalize fills the fields of the group. decoded holds the fields which were
+// sent, as priorities of 0 are valid.
+func (f *FileGroup) normalize(decoded map[string]bool) {
+	if f.NZBID == 0 {
+		f.NZBID = firstNonZero(f.FirstID, f.LastID)
+	}
+	if f.FirstID == 0 {
+		f.FirstID = f.NZBID
+	}
+	if f.LastID == 0 {
+		f.LastID = f.NZBID
+	}
+
+	if f.NZBName == "" {
+		f.NZBName = f.NZBNicename
+	}
+	if f.NZBNicename == "" {
+		f.NZBNicename = f.NZBName
+	}
+
+	if !decoded["MaxPriority"] {
+		f.MaxPriority = f.MinPriority
+	}
+	if !decoded["MinPriority"] {
+		f.MinPriority = f.MaxPriority
+	}
+
+	if f.Kind == "" {
+		f.Kind = "NZB"
+	}
+	f.DeleteStatus = normalizeDeleteStatus(f.DeleteStatus, f.Deleted)
+	f.Deleted = f.DeleteStatus.IsDeleted()
+	defaultStatuses(&f.ParStatus, &f.ExParStatus, &f.UnpackStatus, &f.MoveStatus,
+		&f.ScriptStatus, &f.MarkStatus, &f.URLStatus)
+}
+
+// UnmarshalJSON decodes a history entry, keeping unknown fields in Extra, and
+// normalizes deprecated fields.
+func (h *HistoricalEntry) UnmarshalJSON(data []byte) error {
+	type historicalEntry HistoricalEntry
+	extra, err := decodeObject(data, (*historicalEntry)(h))
+	if err != nil {
+		return err
+	}
+	h.Extra = extra
+	h.normalize()
+	return nil
+}
+
+func (h *HistoricalEntry) normalize() {
+	if h.NZBID == 0 {
+		h.NZBID = h.ID
+	}
+	if h.ID == 0 {
+		h.ID = h.NZBID
+	}
+
+	if h.Name == "" {
+		h.Name = firstNonEmpty(h.NZBName, h.NZBNicename)
+	}
+	if h.Kind == "DUP" {
+		// Hidden duplicates only carry their dupe fields, there is nothing
+		// else to normalize.
+		return
+	}
+	if h.NZBName == "" {
+		h.NZBName = h.Name
+	}
+	if h.NZBNicename == "" {
+		h.NZBNicename = h.NZBName
+	}
+
+	if h.Kind == "" {
+		h.Kind = "NZB"
+	}
+	h.DeleteStatus = normalizeDeleteStatus(h.DeleteStatus, h.Deleted)
+	h.Deleted = h.DeleteStatus.IsDeleted()
+	defaultStatuses(&h.ParStatus, &h.ExParStatus, &h.UnpackStatus, &h.MoveStatus,
+		&h.ScriptStatus, &h.MarkStatus, &h.URLStatus)
+}
+
+// normalizeDeleteStatus derives the delete status of servers which only
+// report the deprecated flag Deleted. Such servers did not record why an item
+// was deleted, so a deletion is reported as MANUAL.
+func normalizeDeleteStatus(status DeleteStatus, deleted bool) DeleteStatus {
+	if status != "" {
+		return status
+	}
+	if deleted {
+		return DeleteStatusManual
+	}
+	return DeleteStatusNone
+}
+
+// defaultStatuses sets the statuses older servers do not send to NONE.
+func defaultStatuses(par *ParStatus, exPar *ExParStatus, unpack *UnpackStatus, move *MoveStatus,
+	script *ScriptResult, mark *MarkStatus, url *URLStatus) {
+	if *par == "" {
+		*par = ParStatusNone
+	}
+	if *exPar == "" {
+		*exPar = ExParStatusNone
+	}
+	if *unpack == "" {
+		*unpack = UnpackStatusNone
+	}
+	if *move == "" {
+		*move = MoveStatusNone
+	}
+	if *script == "" {
+		*script = ScriptResultNone
+	}
+	if *mark == "" {
+		*mark = MarkStatusNone
+	}
+	if *url == "" {
+		*url = URLStatusNone
+	}
+}
+
+func firstNonZero(values ...int) int {
+	for _, value := range values {
+		if value != 0 {
+			return value
+		}
+	}
+	return 0
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
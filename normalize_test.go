@@ -0,0 +1,100 @@
+package nzbget_test
+
+import (
+	"encoding/json"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Normalization", func() {
+
+	Context("file groups", func() {
+		It("should fill new fields from deprecated ones", func() {
+			var group nzbget.FileGroup
+			err := json.Unmarshal([]byte(`{
+				"FirstID": 12,
+				"LastID": 12,
+				"NZBNicename": "My_File_1",
+				"MinPriority": 50,
+				"Deleted": true,
+				"Status": "PP_FINISHED"
+			}`), &group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(group).To(MatchFields(IgnoreExtras, Fields{
+				"NZBID":        Equal(12),
+				"NZBName":      Equal("My_File_1"),
+				"MaxPriority":  Equal(50),
+				"DeleteStatus": Equal(nzbget.DeleteStatusManual),
+				"Kind":         Equal("NZB"),
+				"ParStatus":    Equal(nzbget.ParStatusNone),
+				"MarkStatus":   Equal(nzbget.MarkStatusNone),
+				"URLStatus":    Equal(nzbget.URLStatusNone),
+			}))
+		})
+
+		It("should fill deprecated fields from new ones", func() {
+			var group nzbget.FileGroup
+			err := json.Unmarshal([]byte(`{
+				"NZBID": 15,
+				"NZBName": "My_File_2",
+				"MaxPriority": -50,
+				"DeleteStatus": "HEALTH",
+				"Kind": "URL"
+			}`), &group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(group).To(MatchFields(IgnoreExtras, Fields{
+				"FirstID":     Equal(15),
+				"LastID":      Equal(15),
+				"NZBNicename": Equal("My_File_2"),
+				"MinPriority": Equal(-50),
+				"Deleted":     BeTrue(),
+				"Kind":        Equal("URL"),
+			}))
+		})
+
+		It("should keep a normal priority", func() {
+			var group nzbget.FileGroup
+			err := json.Unmarshal([]byte(`{"NZBID": 15, "MinPriority": -100, "MaxPriority": 0}`), &group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(group.MinPriority).To(Equal(-100))
+			Expect(group.MaxPriority).To(Equal(nzbget.PriorityNormal))
+		})
+
+		It("should prefer the new fields", func() {
+			var group nzbget.FileGroup
+			err := json.Unmarshal([]byte(`{"NZBID": 15, "FirstID": 3, "DeleteStatus": "NONE", "Deleted": true}`), &group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(group.NZBID).To(Equal(15))
+			Expect(group.DeleteStatus).To(Equal(nzbget.DeleteStatusNone))
+			Expect(group.Deleted).To(BeFalse())
+		})
+	})
+
+	Context("history", func() {
+		It("should fill new fields from deprecated ones", func() {
+			var entry nzbget.HistoricalEntry
+			err := json.Unmarshal([]byte(`{"ID": 7, "NZBNicename": "My_File_3", "Deleted": false}`), &entry)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entry).To(MatchFields(IgnoreExtras, Fields{
+				"NZBID":        Equal(7),
+				"Name":         Equal("My_File_3"),
+				"NZBName":      Equal("My_File_3"),
+				"DeleteStatus": Equal(nzbget.DeleteStatusNone),
+				"Kind":         Equal("NZB"),
+				"UnpackStatus": Equal(nzbget.UnpackStatusNone),
+			}))
+		})
+
+		It("should leave hidden duplicates alone", func() {
+			var entry nzbget.HistoricalEntry
+			err := json.Unmarshal([]byte(`{"ID": 8, "Name": "My_File_4", "Kind": "DUP", "DupStatus": "SUCCESS"}`), &entry)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entry.NZBID).To(Equal(8))
+			Expect(entry.NZBName).To(BeEmpty())
+			Expect(entry.DeleteStatus).To(BeEmpty())
+		})
+	})
+})
@@ -1,7 +1,9 @@
 package nzbget
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"net/url"
@@ -30,9 +32,31 @@ type NZBGet struct {
 	password string
 }
 
+type request struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
 type response struct {
 	Result  json.RawMessage `json:"result"`
 	Version string          `json:"version"`
+	Error   *RPCError       `json:"error"`
+}
+
+// RPCError is an error reported by the server for a failed method call
+type RPCError struct {
+	// Name is the name of the error, usually “JSONRPCError”.
+	Name string `json:"name"`
+
+	// Code is the error code.
+	Code int `json:"code"`
+
+	// Message is the human readable description of the error.
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("nzbget: %s (code %d): %s", e.Name, e.Code, e.Message)
 }
 
 // Config returns the server configuration
@@ -83,7 +107,7 @@ type FileGroup struct {
 	//    COPY - v16.0 the download was deleted by duplicate check because an
 	//   		nzb-file with exactly same content exists in download queue or
 	//  		in history.
-	DeleteStatus string `json:"DeleteStatus"`
+	DeleteStatus DeleteStatus `json:"DeleteStatus"`
 
 	// Deleted is deprecated, use DeleteStatus instead
 	Deleted bool `json:"Deleted"`
@@ -119,7 +143,7 @@ type FileGroup struct {
 	//
 	//    RECIPIENT - repaired using blocks from other duplicates;
 	//    DONOR - has donated blocks to repair another duplicate;
-	ExParStatus string `json:"ExParStatus"`
+	ExParStatus ExParStatus `json:"ExParStatus"`
 
 	// ExtraParBlocks is the amount of extra par-blocks received from other
 	// duplicates or donated to other duplicates, when duplicate par-scan mode
@@ -178,7 +202,7 @@ type FileGroup struct {
 	//   		 good in history dialog;
 	//    BAD - the download was marked as bad by user using command Mark as bad
 	//   		in history dialog;
-	MarkStatus string `json:"MarkStatus"`
+	MarkStatus MarkStatus `json:"MarkStatus"`
 
 	// MaxPostTime is the date/time when the newest file in the group was
 	// posted to newsgroup (Time is in C/Unix format).
@@ -206,7 +230,7 @@ type FileGroup struct {
 	//   		 not in use or the par-check or unpack have failed;
 	//    SUCCESS - files were moved successfully;
 	//    FAILURE - the moving has failed.
-	MoveStatus string `json:"MoveStatus"`
+	MoveStatus MoveStatus `json:"MoveStatus"`
 
 	// NZBFilename is the name of nzb-file, this file was added to queue from.
 	// The filename could include fullpath (if client sent it by adding the file
@@ -234,18 +258,14 @@ type FileGroup struct {
 	//    SUCCESS - par-check was successful;
 	//    MANUAL - download is damaged but was not checked/repaired because
 	//   		   option ParCheck is set to Manual.
-	ParStatus string `json:"ParStatus"`
+	ParStatus ParStatus `json:"ParStatus"`
 
 	// ParTimeSec is the par-check time in seconds (incl. verification and
 	// repair).
 	ParTimeSec int `json:"ParTimeSec"`
 
-	// Parameters is the post-processing parameters for group. An array of
-	// structures with following fields:
-	Parameters []struct {
-		Name  string `json:"Name"`
-		Value string `json:"Value"`
-	} `json:"Parameters"`
+	// Parameters is the post-processing parameters for group.
+	Parameters Parameters `json:"Parameters"`
 
 	// PausedSizeHi is the size of all paused files in group in bytes, High
 	// 32-bits of 64-bit value.
@@ -301,21 +321,13 @@ type FileGroup struct {
 	// ScriptStatus is the accumulated result of all post-processing scripts.
 	// One of the predefined text constants: NONE, FAILURE, SUCCESS. Also see
 	// field ScriptStatuses.
-	ScriptStatus string `json:"ScriptStatus"`
+	ScriptStatus ScriptResult `json:"ScriptStatus"`
 
 	// ScriptStatuses is the status info of each post-processing script.
-	ScriptStatuses []interface{} `json:"ScriptStatuses"`
+	ScriptStatuses []ScriptStatus `json:"ScriptStatuses"`
 
 	// ServerStats is the per news-server download statistics.
-	ServerStats []struct {
-		// FailedArticles (int) - Number of failed articles.
-		FailedArticles int `json:"FailedArticles"`
-		// ServerID is the server number as defined in section “news servers”
-		// of the configuration file.
-		ServerID int `json:"ServerID"`
-		// SuccessArticles is the number of successfully downloaded articles.
-		SuccessArticles int `json:"SuccessArticles"`
-	} `json:"ServerStats"`
+	ServerStats []ServerStat `json:"ServerStats"`
 
 	// Status is the status of the group:
 	//
@@ -335,7 +347,7 @@ type FileGroup struct {
 	//    EXECUTING_SCRIPT - executing post-processing script;
 	//    PP_FINISHED - post-processing is finished, the item is about to be
 	//     				moved to history.
-	Status string `json:"Status"`
+	Status GroupStatus `json:"Status"`
 
 	// SuccessArticles is the number of successfully downloaded articles.
 	SuccessArticles int `json:"SuccessArticles"`
@@ -356,7 +368,7 @@ type FileGroup struct {
 	//    PASSWORD - unpack has failed because the password was not provided or
 	//   			 was wrong. Only for rar5-archives;
 	//    SUCCESS - unpack was successful.
-	UnpackStatus string `json:"UnpackStatus"`
+	UnpackStatus UnpackStatus `json:"UnpackStatus"`
 
 	// UnpackTimeSec (int) - v14.0 Unpack time in seconds.
 	UnpackTimeSec int `json:"UnpackTimeSec"`
@@ -373,7 +385,10 @@ type FileGroup struct {
 	//  				 file isn’t a proper nzb-file. This status usually means
 	// 					 the web-server has returned an error page (HTML page)
 	//					 instead of the nzb-file.
-	URLStatus string `json:"UrlStatus"`
+	URLStatus URLStatus `json:"UrlStatus"`
+
+	// Extra holds the fields which could not be decoded, as received.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // FileGroups returns the list of all file groups
@@ -484,13 +499,7 @@ type Status struct {
 	MonthSizeMB int `json:"MonthSizeMB"`
 
 	// NewsServers is the status of news-servers
-	NewsServers []struct {
-		// Active is true if server is in active state (enabled).
-		Active bool `json:"Active"`
-
-		// ID is the server number in the configuration file
-		ID int `json:"ID"`
-	} `json:"NewsServers"`
+	NewsServers []ServerState `json:"NewsServers"`
 
 	// ParJobCount is deprecated, use PostJobCount instead.
 	ParJobCount int `json:"ParJobCount"`
@@ -547,6 +556,9 @@ type Status struct {
 
 	// UrlCount (int) - Number of URLs in the URL-queue (including current file).
 	URLCount int `json:"UrlCount"`
+
+	// Extra holds the fields which could not be decoded, as received.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Status returns the current status of nzbget
@@ -626,6 +638,9 @@ type ServerVolume struct {
 
 	// FirstDay is the Indicates which calendar day the very first slot of BytesPerDays corresponds to. Details see below.
 	FirstDay int `json:"FirstDay"`
+
+	// Extra holds the fields which could not be decoded, as received.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ServerVolumes returns the current status of nzbget
@@ -647,6 +662,26 @@ type HistoricalEntry struct {
 	// extension. Ready for user-friendly output.
 	Name string `json:"Name"`
 
+	// NZBName is the name of nzb-file, same as Name. Not set for DUP entries.
+	NZBName string `json:"NZBName"`
+
+	// NZBNicename is deprecated, use NZBName instead.
+	NZBNicename string `json:"NZBNicename"`
+
+	// Kind is the kind of history entry:
+	//
+	//    NZB - nzb-file;
+	//    URL - failed URL download, the nzb-file could not be fetched;
+	//    DUP - hidden history entry for a duplicate. Only the fields Name,
+	//   		HistoryTime, Status, DupeKey, DupeScore, DupeMode and DupStatus
+	//  		are set for such entries. Returned only if hidden entries
+	//  		are requested.
+	Kind string `json:"Kind"`
+
+	// URL is the URL the nzb-file was fetched from (Kind=NZB) or should have
+	// been fetched from (Kind=URL).
+	URL string `json:"URL"`
+
 	// RemainingFileCount is the number of parked files in group. If this number
 	// is greater than “0”, the history item can be returned to download queue
 	// using command “HistoryReturn” of method
@@ -660,7 +695,7 @@ type HistoricalEntry struct {
 	HistoryTime int `json:"HistoryTime"`
 
 	// Status Total status of the download. One of the predefined text constants
-	// such as SUCCESS/ALL or FAILURE/UNPACK
+	// such as SUCCESS/ALL or FAILURE/UNPACK. See also method Outcome.
 	Status string `json:"Status"`
 
 	// Log is deprecated, was never really used
@@ -695,14 +730,14 @@ type HistoricalEntry struct {
 	//    SUCCESS - par-check was successful;
 	//    MANUAL - download is damaged but was not checked/repaired because
 	//   		   option ParCheck is set to Manual
-	ParStatus string `json:"ParStatus"`
+	ParStatus ParStatus `json:"ParStatus"`
 
 	// ExParStatus indicates if the download was repaired using duplicate
 	// par-scan mode (option ParScan=dupe):
 	//
 	//    RECIPIENT - repaired using blocks from other duplicates;
 	//    DONOR - has donated blocks to repair another duplicate;
-	ExParStatus string `json:"ExParStatus"`
+	ExParStatus ExParStatus `json:"ExParStatus"`
 
 	// UnpackStatus is the result of unpack:
 	//
@@ -713,7 +748,7 @@ type HistoricalEntry struct {
 	//    PASSWORD - unpack has failed because the password was not provided or
 	//               was wrong. Only for rar5-archives;
 	//    SUCCESS - unpack was successful.
-	UnpackStatus string `json:"UnpackStatus"`
+	UnpackStatus UnpackStatus `json:"UnpackStatus"`
 
 	// MoveStatus is the result of moving files from intermediate directory into
 	// final directory:
@@ -722,10 +757,12 @@ type HistoricalEntry struct {
 	//   		 not in use or the par-check or unpack have failed;
 	//    SUCCESS - files were moved successfully;
 	//    FAILURE - the moving has failed.
-	MoveStatus string `json:"MoveStatus"`
+	MoveStatus MoveStatus `json:"MoveStatus"`
 
-	// ScriptStatus is the
-	ScriptStatus string `json:"ScriptStatus"`
+	// ScriptStatus is the accumulated result of all post-processing scripts.
+	// One of the predefined text constants: NONE, FAILURE, SUCCESS. Also see
+	// field ScriptStatuses.
+	ScriptStatus ScriptResult `json:"ScriptStatus"`
 
 	// DeleteStatus is the indicates if the download was deleted:
 	//
@@ -740,7 +777,7 @@ type HistoricalEntry struct {
 	//    COPY - v16.0 the download was deleted by duplicate check because an
 	//   		 nzb-file with exactly same content exists in download queue or
 	//  		 in history.
-	DeleteStatus string `json:"DeleteStatus"`
+	DeleteStatus DeleteStatus `json:"DeleteStatus"`
 
 	// MarkStatus indicates if the download was marked by user:
 	//
@@ -749,7 +786,7 @@ type HistoricalEntry struct {
 	//           good in history dialog;
 	//    BAD - the download was marked as bad by user using command Mark as bad
 	//   		in history dialog;
-	MarkStatus string `json:"MarkStatus"`
+	MarkStatus MarkStatus `json:"MarkStatus"`
 
 	// URLStatus is the result of URL-download:
 	//
@@ -763,7 +800,7 @@ type HistoricalEntry struct {
 	//  				 file isn’t a proper nzb-file. This status usually means
 	//	 				 the web-server has returned an error page (HTML page)
 	//	 				 instead of the nzb-file.
-	URLStatus string `json:"UrlStatus"`
+	URLStatus URLStatus `json:"UrlStatus"`
 
 	// FileSizeLo is the initial size of all files in group in bytes, Low
 	// 32-bits of 64-bit value
@@ -816,6 +853,17 @@ type HistoricalEntry struct {
 	// DupeMode is the duplicate mode. One of SCORE, ALL, FORCE
 	DupeMode string `json:"DupeMode"`
 
+	// DupStatus is the status of a hidden duplicate entry (Kind=DUP):
+	//
+	//    UNKNOWN - the status is not known;
+	//    SUCCESS - the duplicate was successfully downloaded;
+	//    FAILURE - the download of the duplicate has failed;
+	//    DELETED - the duplicate was deleted by user;
+	//    DUPE - the duplicate was deleted by duplicate check;
+	//    BAD - the duplicate was marked as bad by user;
+	//    GOOD - the duplicate was marked as good by user.
+	DupStatus DupStatus `json:"DupStatus"`
+
 	// Deleted indicates if the entry was deleted
 	Deleted bool `json:"Deleted"`
 
@@ -859,28 +907,46 @@ type HistoricalEntry struct {
 	ExtraParBlocks int `json:"ExtraParBlocks"`
 
 	// Parameters are the post-processing parameters for group
-	Parameters []struct {
-		Name  string `json:"Name"`
-		Value string `json:"Value"`
-	} `json:"Parameters"`
+	Parameters Parameters `json:"Parameters"`
 
 	// ScriptStatuses are the status info of each post-processing script
-	ScriptStatuses []struct {
-		Name   string `json:"Name"`
-		Status string `json:"Status"`
-	} `json:"ScriptStatuses"`
+	ScriptStatuses []ScriptStatus `json:"ScriptStatuses"`
 
 	// ServerStats are the per-server article completion statistics
-	ServerStats []struct {
-		ServerID        int `json:"ServerID"`
-		SuccessArticles int `json:"SuccessArticles"`
-		FailedArticles  int `json:"FailedArticles"`
-	} `json:"ServerStats"`
+	ServerStats []ServerStat `json:"ServerStats"`
+
+	// Extra holds the fields which could not be decoded, as received.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// HistoryOption configures the history request
+type HistoryOption func(*historyOptions)
+
+type historyOptions struct {
+	hidden bool
 }
 
-func (n *NZBGet) History() ([]HistoricalEntry, error) {
+// WithHidden sets whether hidden history entries are returned. Hidden entries
+// are the records of duplicates (Kind=DUP), they are not returned by default.
+func WithHidden(hidden bool) HistoryOption {
+	return func(o *historyOptions) {
+		o.hidden = hidden
+	}
+}
+
+// History returns the items of the history list
+func (n *NZBGet) History(opts ...HistoryOption) ([]HistoricalEntry, error) {
+	var options historyOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	var history []HistoricalEntry
-	err := n.get("history", &history)
+	var err error
+	if options.hidden {
+		err = n.call("history", &history, true)
+	} else {
+		err = n.get("history", &history)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -888,22 +954,53 @@ func (n *NZBGet) History() ([]HistoricalEntry, error) {
 }
 
 func (n NZBGet) get(endpoint string, responseObject interface{}) error {
-	n.baseURL.Path = path.Join("jsonrpc", endpoint)
-	req, err := http.NewRequest("GET", n.baseURL.String(), nil)
+	address := *n.baseURL
+	address.Path = path.Join("jsonrpc", endpoint)
+	req, err := http.NewRequest("GET", address.String(), nil)
 	if err != nil {
 		return err
 	}
+	return n.do(req, responseObject)
+}
+
+// call invokes a method with positional parameters. Methods without
+// parameters are requested with get instead.
+func (n NZBGet) call(method string, responseObject interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(request{Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	endpoint := *n.baseURL
+	endpoint.Path = "jsonrpc"
+	req, err := http.NewRequest("POST", endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return n.do(req, responseObject)
+}
+
+func (n NZBGet) do(req *http.Request, responseObject interface{}) error {
 	req.SetBasicAuth(n.user, n.password)
 	result, err := n.client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer result.Body.Close()
+	if result.StatusCode != http.StatusOK {
+		return fmt.Errorf("nzbget: unexpected response status %s", result.Status)
+	}
 	var response response
 	err = json.NewDecoder(result.Body).Decode(&response)
 	if err != nil {
 		log.Printf("error unmarshaling nzbget status: %v", err)
 		return err
 	}
+	if response.Error != nil {
+		return response.Error
+	}
 	return json.Unmarshal(response.Result, &responseObject)
 }
@@ -1,7 +1,10 @@
 package nzbget_test
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/SemanticallyNull/golandreporter"
 	"github.com/billtomturner/go-nzbget-client"
@@ -18,6 +21,38 @@ func TestNZBGet(t *testing.T) {
 	})
 }
 
+// start runs the runner in the background and returns the channel the result
+// of Run is sent on, to be awaited before the mocks are turned off.
+func start(ctx context.Context, runner interface{ Run(context.Context) error }) chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(ctx)
+	}()
+	return done
+}
+
+// receive reads count values from the channel, of any element type, and
+// returns them as a slice of that type. It fails the spec if a value does not
+// arrive within a second.
+func receive(channel interface{}, count int) interface{} {
+	value := reflect.ValueOf(channel)
+	received := reflect.MakeSlice(reflect.SliceOf(value.Type().Elem()), 0, count)
+	for received.Len() < count {
+		chosen, item, ok := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: value},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(time.Second))},
+		})
+		if chosen == 1 {
+			Fail("timed out waiting for values")
+		}
+		if !ok {
+			Fail("channel closed while waiting for values")
+		}
+		received = reflect.Append(received, item)
+	}
+	return received.Interface()
+}
+
 const (
 	nzbgetURL = "http://localhost:6789"
 	config    = `
@@ -1174,6 +1209,37 @@ const (
       ]
     }
   ]
+}`
+	hiddenHistory = `{
+  "version": "1.1",
+  "result": [
+    {
+      "ID": 15850,
+      "Name": "https:\/\/indexer.example\/getnzb\/3.nzb",
+      "NZBName": "https:\/\/indexer.example\/getnzb\/3.nzb",
+      "Kind": "URL",
+      "URL": "https:\/\/indexer.example\/getnzb\/3.nzb",
+      "HistoryTime": 1589708990,
+      "Status": "FAILURE/FETCH",
+      "UrlStatus": "FAILURE",
+      "DeleteStatus": "NONE",
+      "MarkStatus": "NONE",
+      "DupeKey": "",
+      "DupeScore": 0,
+      "DupeMode": "SCORE"
+    },
+    {
+      "ID": 15849,
+      "Name": "My.Show.S01E01.480p",
+      "Kind": "DUP",
+      "HistoryTime": 1589708000,
+      "Status": "DELETED/DUPE",
+      "DupeKey": "tvdb=1234-S01E01",
+      "DupeScore": 50,
+      "DupeMode": "SCORE",
+      "DupStatus": "DUPE"
+    }
+  ]
 }`
 )
 
@@ -1329,6 +1395,40 @@ var _ = Describe("NZBGet", func() {
 				history, err := client.History()
 				Expect(err).ToNot(HaveOccurred())
 				Expect(len(history)).To(Equal(2))
+				Expect(history[0].Kind).To(Equal("NZB"))
+			})
+		})
+
+		Context("with hidden entries", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "history", "params": []bool{true}}).
+					Reply(200).
+					JSON(hiddenHistory)
+			})
+
+			It("should return the URL and duplicate entries", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				history, err := client.History(nzbget.WithHidden(true))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(history)).To(Equal(2))
+				Expect(history[0]).To(MatchFields(IgnoreExtras, Fields{
+					"Kind":      Equal("URL"),
+					"URL":       Equal("https://indexer.example/getnzb/3.nzb"),
+					"URLStatus": Equal(nzbget.URLStatusFailure),
+				}))
+				Expect(history[1]).To(MatchFields(IgnoreExtras, Fields{
+					"Kind":      Equal("DUP"),
+					"DupeKey":   Equal("tvdb=1234-S01E01"),
+					"DupeScore": Equal(50),
+					"DupStatus": Equal(nzbget.DupStatusDupe),
+				}))
 			})
 		})
 	})
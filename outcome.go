@@ -0,0 +1,150 @@
+package nzbget
+
+import "strings"
+
+// OutcomeCategory is the overall category of the outcome of a download
+type OutcomeCategory string
+
+// Categories of outcomes
+const (
+	OutcomeSuccess OutcomeCategory = "SUCCESS"
+	OutcomeWarning OutcomeCategory = "WARNING"
+	OutcomeFailure OutcomeCategory = "FAILURE"
+	OutcomeDeleted OutcomeCategory = "DELETED"
+)
+
+// Outcome is the total status of a history entry as shown by the web
+// interface, such as SUCCESS/UNPACK, FAILURE/PAR, WARNING/SCRIPT or
+// DELETED/DUPE.
+type Outcome struct {
+	// Category is the overall category of the outcome.
+	Category OutcomeCategory
+
+	// Reason names the stage or check the category results from, e.g. ALL,
+	// UNPACK, PAR, HEALTH, SCRIPT, DUPE.
+	Reason string
+}
+
+// String returns the outcome in the format of HistoricalEntry.Status, e.g.
+// “SUCCESS/ALL”.
+func (o Outcome) String() string {
+	return string(o.Category) + "/" + o.Reason
+}
+
+// ParseOutcome parses a total status in the format of HistoricalEntry.Status.
+func ParseOutcome(status string) Outcome {
+	parts := strings.SplitN(status, "/", 2)
+	if len(parts) < 2 {
+		return Outcome{Category: OutcomeCategory(parts[0])}
+	}
+	return Outcome{Category: OutcomeCategory(parts[0]), Reason: parts[1]}
+}
+
+var internalError = Outcome{OutcomeFailure, "INTERNAL_ERROR"}
+
+// Outcome computes the total status of the entry from the individual statuses
+// and the health, the same way the server does for field Status. It allows
+// entries of servers which report different or no total status to be
+// classified consistently.
+func (h HistoricalEntry) Outcome() Outcome {
+	switch h.Kind {
+	case "URL":
+		return h.urlOutcome()
+	case "DUP":
+		return h.dupOutcome()
+	}
+	return h.nzbOutcome()
+}
+
+func (h HistoricalEntry) nzbOutcome() Outcome {
+	parNone := h.ParStatus == ParStatusNone || h.ParStatus == ""
+	unpackNone := h.UnpackStatus == UnpackStatusNone || h.UnpackStatus == ""
+	scriptNone := h.ScriptStatus == ScriptResultNone || h.ScriptStatus == ""
+
+	switch {
+	case h.MarkStatus == MarkStatusBad:
+		return Outcome{OutcomeFailure, "BAD"}
+	case h.MarkStatus == MarkStatusGood:
+		return Outcome{OutcomeSuccess, "GOOD"}
+	case h.MarkStatus == MarkStatusSuccess:
+		return Outcome{OutcomeSuccess, "MARK"}
+	case h.DeleteStatus == DeleteStatusHealth:
+		return Outcome{OutcomeFailure, "HEALTH"}
+	case h.DeleteStatus == DeleteStatusManual:
+		return Outcome{OutcomeDeleted, "MANUAL"}
+	case h.DeleteStatus == DeleteStatusDupe:
+		return Outcome{OutcomeDeleted, "DUPE"}
+	case h.DeleteStatus == DeleteStatusBad:
+		return Outcome{OutcomeFailure, "BAD"}
+	case h.DeleteStatus == DeleteStatusGood:
+		return Outcome{OutcomeDeleted, "GOOD"}
+	case h.DeleteStatus == DeleteStatusCopy:
+		return Outcome{OutcomeDeleted, "COPY"}
+	case h.DeleteStatus == DeleteStatusScan:
+		return Outcome{OutcomeFailure, "SCAN"}
+	case h.ParStatus == ParStatusFailure:
+		return Outcome{OutcomeFailure, "PAR"}
+	case h.UnpackStatus == UnpackStatusFailure:
+		return Outcome{OutcomeFailure, "UNPACK"}
+	case h.MoveStatus == MoveStatusFailure:
+		return Outcome{OutcomeFailure, "MOVE"}
+	case h.ParStatus == ParStatusManual:
+		return Outcome{OutcomeWarning, "DAMAGED"}
+	case h.ParStatus == ParStatusRepairPossible:
+		return Outcome{OutcomeWarning, "REPAIRABLE"}
+	case parNone && unpackNone && h.Health < h.CriticalHealth:
+		return Outcome{OutcomeFailure, "HEALTH"}
+	case parNone && unpackNone && h.Health < 1000:
+		return Outcome{OutcomeWarning, "HEALTH"}
+	case parNone && unpackNone && h.ScriptStatus != ScriptResultFailure:
+		return Outcome{OutcomeSuccess, "HEALTH"}
+	case h.UnpackStatus == UnpackStatusSpace:
+		return Outcome{OutcomeWarning, "SPACE"}
+	case h.UnpackStatus == UnpackStatusPassword:
+		return Outcome{OutcomeWarning, "PASSWORD"}
+	case (h.UnpackStatus == UnpackStatusSuccess || (unpackNone && h.ParStatus == ParStatusSuccess)) &&
+		h.ScriptStatus == ScriptResultSuccess:
+		return Outcome{OutcomeSuccess, "ALL"}
+	case h.UnpackStatus == UnpackStatusSuccess && scriptNone:
+		return Outcome{OutcomeSuccess, "UNPACK"}
+	case h.ParStatus == ParStatusSuccess && scriptNone:
+		return Outcome{OutcomeSuccess, "PAR"}
+	case h.ScriptStatus == ScriptResultFailure:
+		return Outcome{OutcomeWarning, "SCRIPT"}
+	}
+	return internalError
+}
+
+func (h HistoricalEntry) urlOutcome() Outcome {
+	switch {
+	case h.DeleteStatus == DeleteStatusManual:
+		return Outcome{OutcomeDeleted, "MANUAL"}
+	case h.DeleteStatus == DeleteStatusDupe:
+		return Outcome{OutcomeDeleted, "DUPE"}
+	case h.URLStatus == URLStatusFailure:
+		return Outcome{OutcomeFailure, "FETCH"}
+	case h.URLStatus == URLStatusScanSkipped:
+		return Outcome{OutcomeWarning, "SKIPPED"}
+	case h.URLStatus == URLStatusScanFailure:
+		return Outcome{OutcomeFailure, "SCAN"}
+	}
+	return internalError
+}
+
+func (h HistoricalEntry) dupOutcome() Outcome {
+	switch h.DupStatus {
+	case DupStatusSuccess:
+		return Outcome{OutcomeSuccess, "HIDDEN"}
+	case DupStatusFailure:
+		return Outcome{OutcomeFailure, "HIDDEN"}
+	case DupStatusDeleted:
+		return Outcome{OutcomeDeleted, "MANUAL"}
+	case DupStatusDupe:
+		return Outcome{OutcomeDeleted, "DUPE"}
+	case DupStatusBad:
+		return Outcome{OutcomeFailure, "BAD"}
+	case DupStatusGood:
+		return Outcome{OutcomeSuccess, "GOOD"}
+	}
+	return internalError
+}
@@ -0,0 +1,207 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// nzbEntry returns a history entry of a fully healthy download on which no
+// post-processing was performed.
+func nzbEntry() nzbget.HistoricalEntry {
+	return nzbget.HistoricalEntry{
+		Kind:           "NZB",
+		ParStatus:      nzbget.ParStatusNone,
+		UnpackStatus:   nzbget.UnpackStatusNone,
+		MoveStatus:     nzbget.MoveStatusNone,
+		ScriptStatus:   nzbget.ScriptResultNone,
+		DeleteStatus:   nzbget.DeleteStatusNone,
+		MarkStatus:     nzbget.MarkStatusNone,
+		URLStatus:      nzbget.URLStatusNone,
+		Health:         1000,
+		CriticalHealth: 900,
+	}
+}
+
+var _ = Describe("Outcome", func() {
+
+	DescribeTable("NZB entries",
+		func(modify func(*nzbget.HistoricalEntry), expected string) {
+			entry := nzbEntry()
+			modify(&entry)
+			Expect(entry.Outcome().String()).To(Equal(expected))
+		},
+		Entry("marked bad", func(h *nzbget.HistoricalEntry) {
+			h.MarkStatus = nzbget.MarkStatusBad
+			h.UnpackStatus = nzbget.UnpackStatusSuccess
+		}, "FAILURE/BAD"),
+		Entry("marked good", func(h *nzbget.HistoricalEntry) {
+			h.MarkStatus = nzbget.MarkStatusGood
+			h.ParStatus = nzbget.ParStatusFailure
+		}, "SUCCESS/GOOD"),
+		Entry("marked success", func(h *nzbget.HistoricalEntry) {
+			h.MarkStatus = nzbget.MarkStatusSuccess
+			h.DeleteStatus = nzbget.DeleteStatusHealth
+		}, "SUCCESS/MARK"),
+		Entry("deleted by health check", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusHealth
+		}, "FAILURE/HEALTH"),
+		Entry("deleted manually", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusManual
+		}, "DELETED/MANUAL"),
+		Entry("deleted by duplicate check", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusDupe
+		}, "DELETED/DUPE"),
+		Entry("deleted as bad by queue-script", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusBad
+		}, "FAILURE/BAD"),
+		Entry("deleted as good", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusGood
+		}, "DELETED/GOOD"),
+		Entry("deleted as copy", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusCopy
+		}, "DELETED/COPY"),
+		Entry("deleted as malformed", func(h *nzbget.HistoricalEntry) {
+			h.DeleteStatus = nzbget.DeleteStatusScan
+		}, "FAILURE/SCAN"),
+		Entry("par failure", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusFailure
+			h.UnpackStatus = nzbget.UnpackStatusFailure
+		}, "FAILURE/PAR"),
+		Entry("unpack failure", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusSuccess
+			h.UnpackStatus = nzbget.UnpackStatusFailure
+		}, "FAILURE/UNPACK"),
+		Entry("move failure", func(h *nzbget.HistoricalEntry) {
+			h.UnpackStatus = nzbget.UnpackStatusSuccess
+			h.MoveStatus = nzbget.MoveStatusFailure
+		}, "FAILURE/MOVE"),
+		Entry("damaged with manual par-check", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusManual
+		}, "WARNING/DAMAGED"),
+		Entry("repairable", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusRepairPossible
+		}, "WARNING/REPAIRABLE"),
+		Entry("health below critical", func(h *nzbget.HistoricalEntry) {
+			h.Health = 850
+		}, "FAILURE/HEALTH"),
+		Entry("health reduced", func(h *nzbget.HistoricalEntry) {
+			h.Health = 950
+		}, "WARNING/HEALTH"),
+		Entry("health reduced with script failure", func(h *nzbget.HistoricalEntry) {
+			h.Health = 950
+			h.ScriptStatus = nzbget.ScriptResultFailure
+		}, "WARNING/HEALTH"),
+		Entry("full health", func(h *nzbget.HistoricalEntry) {}, "SUCCESS/HEALTH"),
+		Entry("full health with script success", func(h *nzbget.HistoricalEntry) {
+			h.ScriptStatus = nzbget.ScriptResultSuccess
+		}, "SUCCESS/HEALTH"),
+		Entry("full health with script failure", func(h *nzbget.HistoricalEntry) {
+			h.ScriptStatus = nzbget.ScriptResultFailure
+		}, "WARNING/SCRIPT"),
+		Entry("unpack without disk space", func(h *nzbget.HistoricalEntry) {
+			h.UnpackStatus = nzbget.UnpackStatusSpace
+		}, "WARNING/SPACE"),
+		Entry("unpack without password", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusSuccess
+			h.UnpackStatus = nzbget.UnpackStatusPassword
+		}, "WARNING/PASSWORD"),
+		Entry("unpack and script success", func(h *nzbget.HistoricalEntry) {
+			h.UnpackStatus = nzbget.UnpackStatusSuccess
+			h.ScriptStatus = nzbget.ScriptResultSuccess
+		}, "SUCCESS/ALL"),
+		Entry("par and script success", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusSuccess
+			h.ScriptStatus = nzbget.ScriptResultSuccess
+		}, "SUCCESS/ALL"),
+		Entry("unpack success", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusSuccess
+			h.UnpackStatus = nzbget.UnpackStatusSuccess
+		}, "SUCCESS/UNPACK"),
+		Entry("par success", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusSuccess
+		}, "SUCCESS/PAR"),
+		Entry("unpack success with script failure", func(h *nzbget.HistoricalEntry) {
+			h.UnpackStatus = nzbget.UnpackStatusSuccess
+			h.ScriptStatus = nzbget.ScriptResultFailure
+		}, "WARNING/SCRIPT"),
+		Entry("par success with script failure and reduced health", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatusSuccess
+			h.ScriptStatus = nzbget.ScriptResultFailure
+			h.Health = 800
+		}, "WARNING/SCRIPT"),
+		Entry("entry without kind", func(h *nzbget.HistoricalEntry) {
+			h.Kind = ""
+			h.UnpackStatus = nzbget.UnpackStatusSuccess
+		}, "SUCCESS/UNPACK"),
+		Entry("unexpected statuses", func(h *nzbget.HistoricalEntry) {
+			h.ParStatus = nzbget.ParStatus("SKIPPED")
+		}, "FAILURE/INTERNAL_ERROR"),
+	)
+
+	DescribeTable("URL entries",
+		func(deleteStatus nzbget.DeleteStatus, urlStatus nzbget.URLStatus, expected string) {
+			entry := nzbget.HistoricalEntry{Kind: "URL", DeleteStatus: deleteStatus, URLStatus: urlStatus}
+			Expect(entry.Outcome().String()).To(Equal(expected))
+		},
+		Entry("deleted manually", nzbget.DeleteStatusManual, nzbget.URLStatusFailure, "DELETED/MANUAL"),
+		Entry("deleted by duplicate check", nzbget.DeleteStatusDupe, nzbget.URLStatusNone, "DELETED/DUPE"),
+		Entry("fetch failure", nzbget.DeleteStatusNone, nzbget.URLStatusFailure, "FAILURE/FETCH"),
+		Entry("scan skipped", nzbget.DeleteStatusNone, nzbget.URLStatusScanSkipped, "WARNING/SKIPPED"),
+		Entry("scan failure", nzbget.DeleteStatusNone, nzbget.URLStatusScanFailure, "FAILURE/SCAN"),
+		Entry("success", nzbget.DeleteStatusNone, nzbget.URLStatusSuccess, "FAILURE/INTERNAL_ERROR"),
+	)
+
+	DescribeTable("DUP entries",
+		func(dupStatus nzbget.DupStatus, expected string) {
+			entry := nzbget.HistoricalEntry{Kind: "DUP", DupStatus: dupStatus}
+			Expect(entry.Outcome().String()).To(Equal(expected))
+		},
+		Entry("success", nzbget.DupStatusSuccess, "SUCCESS/HIDDEN"),
+		Entry("failure", nzbget.DupStatusFailure, "FAILURE/HIDDEN"),
+		Entry("deleted", nzbget.DupStatusDeleted, "DELETED/MANUAL"),
+		Entry("dupe", nzbget.DupStatusDupe, "DELETED/DUPE"),
+		Entry("bad", nzbget.DupStatusBad, "FAILURE/BAD"),
+		Entry("good", nzbget.DupStatusGood, "SUCCESS/GOOD"),
+		Entry("unknown", nzbget.DupStatusUnknown, "FAILURE/INTERNAL_ERROR"),
+	)
+
+	DescribeTable("ParseOutcome",
+		func(status string, expected nzbget.Outcome) {
+			Expect(nzbget.ParseOutcome(status)).To(Equal(expected))
+		},
+		Entry("category and reason", "WARNING/SCRIPT", nzbget.Outcome{Category: nzbget.OutcomeWarning, Reason: "SCRIPT"}),
+		Entry("category only", "FAILURE", nzbget.Outcome{Category: nzbget.OutcomeFailure}),
+	)
+
+	Context("history", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Post("/jsonrpc").
+				Reply(200).
+				JSON(hiddenHistory)
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/history").
+				Reply(200).
+				JSON(history)
+		})
+
+		It("should agree with the status reported by the server", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			visible, err := client.History()
+			Expect(err).ToNot(HaveOccurred())
+			hidden, err := client.History(nzbget.WithHidden(true))
+			Expect(err).ToNot(HaveOccurred())
+			for _, entry := range append(visible, hidden...) {
+				Expect(entry.Outcome().String()).To(Equal(entry.Status), entry.Name)
+			}
+		})
+	})
+})
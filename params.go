@@ -0,0 +1,135 @@
+package nzbget
+
+import "strings"
+
+// ScriptStatus is the result of a single post-processing script.
+type ScriptStatus struct {
+	// Name is the name of the script.
+	Name string `json:"Name"`
+
+	// Status is the result of the script: NONE, FAILURE or SUCCESS.
+	Status ScriptResult `json:"Status"`
+}
+
+// Parameter is a post-processing parameter of a group.
+type Parameter struct {
+	// Name is the name of the parameter.
+	Name string `json:"Name"`
+
+	// Value is the value of the parameter.
+	Value string `json:"Value"`
+}
+
+// Parameters is the list of post-processing parameters of a group, in the
+// order sent by the server.
+//
+// Parameter names are namespaced. Names of the form “MyScript.py:” enable the
+// post-processing script MyScript.py for the group and “MyScript.py:Option”
+// set option Option of that script. Names starting with an asterisk belong to
+// NZBGet itself, e.g. “*Unpack:” enables or disables unpack and
+// “*Unpack:Password” holds the unpack password.
+type Parameters []Parameter
+
+// Names of the parameters NZBGet itself uses
+const (
+	ParamUnpack         = "*Unpack:"
+	ParamUnpackPassword = "*Unpack:Password"
+)
+
+// Get returns the value of the parameter with the given name or empty string
+// if there is no such parameter. If the name occurs more than once the last
+// value wins, as on the server.
+func (p Parameters) Get(name string) string {
+	value, _ := p.Lookup(name)
+	return value
+}
+
+// Lookup returns the value of the parameter with the given name and whether
+// the parameter is present.
+func (p Parameters) Lookup(name string) (string, bool) {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].Name == name {
+			return p[i].Value, true
+		}
+	}
+	return "", false
+}
+
+// Has reports whether the parameter with the given name is present.
+func (p Parameters) Has(name string) bool {
+	_, ok := p.Lookup(name)
+	return ok
+}
+
+// Map returns the parameters as a map from name to value.
+func (p Parameters) Map() map[string]string {
+	m := make(map[string]string, len(p))
+	for _, param := range p {
+		m[param.Name] = param.Value
+	}
+	return m
+}
+
+// Script returns the options of the given script, with the script prefix
+// removed from the names. The parameter enabling the script is not included.
+func (p Parameters) Script(script string) Parameters {
+	prefix := script + ":"
+	var options Parameters
+	for _, param := range p {
+		if strings.HasPrefix(param.Name, prefix) && len(param.Name) > len(prefix) {
+			options = append(options, Parameter{Name: param.Name[len(prefix):], Value: param.Value})
+		}
+	}
+	return options
+}
+
+// ScriptEnabled reports whether the given post-processing script is enabled
+// for the group.
+func (p Parameters) ScriptEnabled(script string) bool {
+	return isYes(p.Get(script + ":"))
+}
+
+// Scripts returns the names of the post-processing scripts enabled for the
+// group.
+func (p Parameters) Scripts() []string {
+	var scripts []string
+	for _, param := range p {
+		if strings.HasPrefix(param.Name, "*") || !strings.HasSuffix(param.Name, ":") {
+			continue
+		}
+		script := strings.TrimSuffix(param.Name, ":")
+		if p.ScriptEnabled(script) && !containsString(scripts, script) {
+			scripts = append(scripts, script)
+		}
+	}
+	return scripts
+}
+
+// Unpack returns whether unpack is enabled for the group. The second return
+// value is false if the group does not override the global setting.
+func (p Parameters) Unpack() (bool, bool) {
+	value, ok := p.Lookup(ParamUnpack)
+	if !ok {
+		return false, false
+	}
+	return isYes(value), true
+}
+
+// UnpackPassword returns the password used to unpack the group or empty
+// string if none is set.
+func (p Parameters) UnpackPassword() string {
+	return p.Get(ParamUnpackPassword)
+}
+
+func isYes(value string) bool {
+	return strings.EqualFold(value, "yes")
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package nzbget_test
+
+import (
+	"encoding/json"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Parameters", func() {
+	var params nzbget.Parameters
+
+	BeforeEach(func() {
+		params = nzbget.Parameters{
+			{Name: "*Unpack:", Value: "yes"},
+			{Name: "*Unpack:Password", Value: "secret"},
+			{Name: "VideoSort.py:", Value: "yes"},
+			{Name: "VideoSort.py:MoviesFormat", Value: "%t (%y)"},
+			{Name: "Notify.py:", Value: "no"},
+			{Name: "Notify.py:Url", Value: "https://example.com"},
+			{Name: "drone", Value: "b1b83444492a4bc8846641107070a3bf"},
+			{Name: "drone", Value: "c0ffee"},
+		}
+	})
+
+	It("should look up parameters", func() {
+		Expect(params.Get("drone")).To(Equal("c0ffee"))
+		Expect(params.Has("drone")).To(BeTrue())
+		Expect(params.Has("missing")).To(BeFalse())
+		Expect(params.Get("missing")).To(BeEmpty())
+		Expect(params.Map()).To(HaveKeyWithValue("VideoSort.py:MoviesFormat", "%t (%y)"))
+	})
+
+	It("should return the script namespaces", func() {
+		Expect(params.Scripts()).To(Equal([]string{"VideoSort.py"}))
+		Expect(params.ScriptEnabled("VideoSort.py")).To(BeTrue())
+		Expect(params.ScriptEnabled("Notify.py")).To(BeFalse())
+		Expect(params.Script("Notify.py")).To(Equal(nzbget.Parameters{{Name: "Url", Value: "https://example.com"}}))
+		Expect(params.Script("VideoSort.py").Get("MoviesFormat")).To(Equal("%t (%y)"))
+	})
+
+	It("should return the unpack settings", func() {
+		enabled, set := params.Unpack()
+		Expect(enabled).To(BeTrue())
+		Expect(set).To(BeTrue())
+		Expect(params.UnpackPassword()).To(Equal("secret"))
+
+		_, set = nzbget.Parameters{}.Unpack()
+		Expect(set).To(BeFalse())
+	})
+
+	It("should decode script statuses of file groups", func() {
+		var group nzbget.FileGroup
+		err := json.Unmarshal([]byte(`{"ScriptStatuses": [{"Name": "VideoSort.py", "Status": "SUCCESS"}]}`), &group)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(group.ScriptStatuses).To(Equal([]nzbget.ScriptStatus{{Name: "VideoSort.py", Status: nzbget.ScriptResultSuccess}}))
+	})
+
+	Context("file groups and history", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/listgroups").
+				Reply(200).
+				JSON(listGroups)
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/history").
+				Reply(200).
+				JSON(history)
+		})
+
+		It("should share the parameter types", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			groups, err := client.FileGroups()
+			Expect(err).ToNot(HaveOccurred())
+			history, err := client.History()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(groups[0].Parameters.Get("drone")).To(Equal("b1b83444492a4bc8846641107070a3bf"))
+			enabled, _ := groups[0].Parameters.Unpack()
+			Expect(enabled).To(BeTrue())
+			enabled, _ = history[0].Parameters.Unpack()
+			Expect(enabled).To(BeFalse())
+		})
+	})
+})
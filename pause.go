@@ -0,0 +1,32 @@
+package nzbget
+
+// PauseDownload pauses downloading of the whole queue. Groups with force
+// priority are still downloaded.
+func (n NZBGet) PauseDownload() (bool, error) {
+	return n.command("pausedownload")
+}
+
+// ResumeDownload resumes downloading of the queue.
+func (n NZBGet) ResumeDownload() (bool, error) {
+	return n.command("resumedownload")
+}
+
+// PausePost pauses post-processing. A running step is finished first.
+func (n NZBGet) PausePost() (bool, error) {
+	return n.command("pausepost")
+}
+
+// ResumePost resumes post-processing.
+func (n NZBGet) ResumePost() (bool, error) {
+	return n.command("resumepost")
+}
+
+// command calls a method without parameters which reports success.
+func (n NZBGet) command(method string) (bool, error) {
+	var ok bool
+	err := n.get(method, &ok)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
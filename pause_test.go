@@ -0,0 +1,51 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#PauseDownload", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/pausedownload").
+				Reply(200).
+				JSON(`{"version": "1.1", "result": true}`)
+		})
+
+		It("should pause downloading", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			ok, err := client.PauseDownload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Context("#ResumePost", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/resumepost").
+				Reply(500)
+		})
+
+		It("should return the error", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.ResumePost()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// pollEvery calls poll right away and then once per interval until the
+// context is done, and returns the context error. It is the loop of the
+// monitors: poll fetches what it needs from the server and skips the round
+// if that fails, so a server which is down for a while does not stop the
+// monitor.
+func pollEvery(ctx context.Context, interval time.Duration, poll func()) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		poll()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package nzbget
+
+import (
+	"sort"
+	"time"
+)
+
+// Priorities of groups as offered by the web interface. Any other value is
+// accepted by the server as well.
+const (
+	PriorityVeryLow  = -100
+	PriorityLow      = -50
+	PriorityNormal   = 0
+	PriorityHigh     = 50
+	PriorityVeryHigh = 100
+
+	// PriorityForce is the priority of groups which are downloaded even if
+	// the download queue is paused.
+	PriorityForce = 900
+)
+
+// downloadSizes returns the total and remaining size of the group without the
+// paused files, which are usually extra par-files only downloaded on demand.
+func (f FileGroup) downloadSizes() (total, remaining Size) {
+	paused := f.PausedSize()
+	total, remaining = f.FileSize(), f.RemainingSize()
+	if total > paused {
+		total -= paused
+	} else {
+		total = 0
+	}
+	if remaining > paused {
+		remaining -= paused
+	} else {
+		remaining = 0
+	}
+	return total, remaining
+}
+
+// Progress returns the download progress of the group between 0 and 1. Like
+// the web interface, paused files are left out so that a group which only
+// has its extra par-files left is reported as complete.
+func (f FileGroup) Progress() float64 {
+	total, remaining := f.downloadSizes()
+	if total == 0 {
+		if f.FileSize() == 0 && !f.Status.IsPostProcessing() {
+			return 0
+		}
+		return 1
+	}
+	if remaining > total {
+		return 0
+	}
+	return float64(total-remaining) / float64(total)
+}
+
+// PostProgress returns the progress of the current post-processing stage
+// between 0 and 1. The second return value is false if the group is not
+// being post-processed, in which case PostStageProgress holds no meaningful
+// value.
+func (f FileGroup) PostProgress() (float64, bool) {
+	if !f.Status.IsPostProcessing() || f.Status == GroupStatusPPQueued {
+		return 0, false
+	}
+	progress := f.PostStageProgress
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1000 {
+		progress = 1000
+	}
+	return float64(progress) / 1000, true
+}
+
+// ETA returns the time needed to download the rest of the group at the given
+// rate in bytes per second. The second return value is false if the rate is
+// not positive and the group has data left.
+func (f FileGroup) ETA(rate int) (time.Duration, bool) {
+	_, remaining := f.downloadSizes()
+	return eta(remaining, rate)
+}
+
+func eta(remaining Size, rate int) (time.Duration, bool) {
+	if remaining == 0 {
+		return 0, true
+	}
+	if rate <= 0 {
+		return 0, false
+	}
+	return time.Duration(float64(remaining) / float64(rate) * float64(time.Second)), true
+}
+
+// EstimationRate returns the download rate, in bytes per second, used to
+// estimate remaining times: the current rate, or the average rate since
+// server start while nothing is being downloaded, capped by the download
+// limit.
+func (s Status) EstimationRate() int {
+	rate := s.DownloadRate
+	if rate <= 0 {
+		rate = s.AverageDownloadRate
+	}
+	if s.DownloadLimit > 0 && rate > s.DownloadLimit {
+		rate = s.DownloadLimit
+	}
+	return rate
+}
+
+// ItemETA is the estimated remaining time of a group in the download queue.
+type ItemETA struct {
+	// NZBID is the ID of the group.
+	NZBID int
+
+	// Remaining is the size left to download, without paused files.
+	Remaining Size
+
+	// ETA is the time until the group is completely downloaded, including
+	// the time needed for all groups downloaded before it.
+	ETA time.Duration
+
+	// Known is false if the group will not be downloaded at the current
+	// state, e.g. because it or the queue is paused or the rate is unknown.
+	Known bool
+}
+
+// QueueEstimate is the estimated remaining time of the download queue.
+type QueueEstimate struct {
+	// Items are the estimates of each group in the order they are
+	// downloaded.
+	Items []ItemETA
+
+	// Total is the time until all groups with a known ETA are downloaded.
+	Total time.Duration
+
+	// Rate is the download rate the estimate is based on, in bytes per
+	// second.
+	Rate int
+}
+
+// Item returns the estimate of the group with the given ID.
+func (q QueueEstimate) Item(nzbID int) (ItemETA, bool) {
+	for _, item := range q.Items {
+		if item.NZBID == nzbID {
+			return item, true
+		}
+	}
+	return ItemETA{}, false
+}
+
+// EstimateQueue estimates when each group of the queue is downloaded. Groups
+// are downloaded one after another by descending priority and, within the
+// same priority, in queue order; paused groups are skipped and while the
+// queue is paused only groups with force priority are downloaded.
+func EstimateQueue(groups []FileGroup, status Status) QueueEstimate {
+	ordered := make([]FileGroup, len(groups))
+	copy(ordered, groups)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].MaxPriority > ordered[j].MaxPriority
+	})
+
+	estimate := QueueEstimate{Rate: status.EstimationRate()}
+	queuePaused := status.DownloadPaused || status.Download2Paused || status.QuotaReached
+	var total Size
+	for _, group := range ordered {
+		_, remaining := group.downloadSizes()
+		item := ItemETA{NZBID: group.NZBID, Remaining: remaining}
+		waiting := group.Status.IsPaused() || (queuePaused && group.MaxPriority < PriorityForce)
+		switch {
+		case remaining == 0:
+			item.Known = true
+		case !waiting:
+			total += remaining
+			item.ETA, item.Known = eta(total, estimate.Rate)
+		}
+		estimate.Items = append(estimate.Items, item)
+	}
+	estimate.Total, _ = eta(total, estimate.Rate)
+	return estimate
+}
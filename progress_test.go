@@ -0,0 +1,119 @@
+package nzbget_test
+
+import (
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// group returns a file group with the given sizes in megabytes.
+func group(nzbID int, status nzbget.GroupStatus, priority, fileMB, remainingMB, pausedMB int) nzbget.FileGroup {
+	return nzbget.FileGroup{
+		NZBID:           nzbID,
+		Status:          status,
+		MaxPriority:     priority,
+		FileSizeLo:      fileMB << 20,
+		RemainingSizeLo: remainingMB << 20,
+		PausedSizeLo:    pausedMB << 20,
+	}
+}
+
+var _ = Describe("Progress", func() {
+
+	DescribeTable("#Progress",
+		func(g nzbget.FileGroup, expected float64) {
+			Expect(g.Progress()).To(BeNumerically("~", expected, 0.001))
+		},
+		Entry("not started", group(1, nzbget.GroupStatusQueued, 0, 100, 100, 0), 0.0),
+		Entry("half done", group(1, nzbget.GroupStatusDownloading, 0, 100, 50, 0), 0.5),
+		Entry("paused par-files excluded", group(1, nzbget.GroupStatusDownloading, 0, 110, 60, 10), 0.5),
+		Entry("only paused files left", group(1, nzbget.GroupStatusPPQueued, 0, 110, 10, 10), 1.0),
+		Entry("empty URL", group(1, nzbget.GroupStatusFetching, 0, 0, 0, 0), 0.0),
+	)
+
+	DescribeTable("#PostProgress",
+		func(status nzbget.GroupStatus, stageProgress int, expected float64, ok bool) {
+			g := nzbget.FileGroup{Status: status, PostStageProgress: stageProgress}
+			progress, known := g.PostProgress()
+			Expect(known).To(Equal(ok))
+			Expect(progress).To(BeNumerically("~", expected, 0.001))
+		},
+		Entry("downloading", nzbget.GroupStatusDownloading, -1639621749, 0.0, false),
+		Entry("queued for post-processing", nzbget.GroupStatusPPQueued, 0, 0.0, false),
+		Entry("unpacking", nzbget.GroupStatusUnpacking, 425, 0.425, true),
+		Entry("out of range", nzbget.GroupStatusRepairing, 1200, 1.0, true),
+	)
+
+	It("should estimate the remaining time of a group", func() {
+		eta, ok := group(1, nzbget.GroupStatusDownloading, 0, 100, 50, 0).ETA(10 << 20)
+		Expect(ok).To(BeTrue())
+		Expect(eta).To(Equal(5 * time.Second))
+		_, ok = group(1, nzbget.GroupStatusDownloading, 0, 100, 50, 0).ETA(0)
+		Expect(ok).To(BeFalse())
+	})
+
+	DescribeTable("#EstimationRate",
+		func(status nzbget.Status, expected int) {
+			Expect(status.EstimationRate()).To(Equal(expected))
+		},
+		Entry("current rate", nzbget.Status{DownloadRate: 100, AverageDownloadRate: 50}, 100),
+		Entry("average rate when idle", nzbget.Status{AverageDownloadRate: 50}, 50),
+		Entry("limited", nzbget.Status{AverageDownloadRate: 50, DownloadLimit: 20}, 20),
+	)
+
+	Context("#EstimateQueue", func() {
+		var groups []nzbget.FileGroup
+
+		BeforeEach(func() {
+			groups = []nzbget.FileGroup{
+				group(1, nzbget.GroupStatusQueued, 0, 100, 100, 0),
+				group(2, nzbget.GroupStatusDownloading, 50, 100, 50, 0),
+				group(3, nzbget.GroupStatusPaused, 100, 100, 100, 0),
+				group(4, nzbget.GroupStatusQueued, 0, 200, 200, 20),
+				group(5, nzbget.GroupStatusUnpacking, 0, 100, 0, 0),
+			}
+		})
+
+		It("should estimate in priority and queue order", func() {
+			estimate := nzbget.EstimateQueue(groups, nzbget.Status{DownloadRate: 10 << 20})
+			var order []int
+			for _, item := range estimate.Items {
+				order = append(order, item.NZBID)
+			}
+			Expect(order).To(Equal([]int{3, 2, 1, 4, 5}))
+
+			paused, _ := estimate.Item(3)
+			Expect(paused.Known).To(BeFalse())
+			second, _ := estimate.Item(2)
+			Expect(second.ETA).To(Equal(5 * time.Second))
+			first, _ := estimate.Item(1)
+			Expect(first.ETA).To(Equal(15 * time.Second))
+			last, _ := estimate.Item(4)
+			Expect(last.ETA).To(Equal(33 * time.Second))
+			done, _ := estimate.Item(5)
+			Expect(done.Known).To(BeTrue())
+			Expect(done.ETA).To(BeZero())
+			Expect(estimate.Total).To(Equal(33 * time.Second))
+		})
+
+		It("should only count forced groups while the queue is paused", func() {
+			groups[0].MaxPriority = nzbget.PriorityForce
+			estimate := nzbget.EstimateQueue(groups, nzbget.Status{DownloadRate: 10 << 20, DownloadPaused: true})
+			forced, _ := estimate.Item(1)
+			Expect(forced.Known).To(BeTrue())
+			Expect(forced.ETA).To(Equal(10 * time.Second))
+			other, _ := estimate.Item(2)
+			Expect(other.Known).To(BeFalse())
+			Expect(estimate.Total).To(Equal(10 * time.Second))
+		})
+
+		It("should not estimate without a rate", func() {
+			estimate := nzbget.EstimateQueue(groups, nzbget.Status{})
+			item, _ := estimate.Item(1)
+			Expect(item.Known).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,332 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// QuotaPeriod is the period a traffic budget is counted over
+type QuotaPeriod string
+
+// Periods of traffic budgets
+const (
+	QuotaDaily   QuotaPeriod = "DAILY"
+	QuotaMonthly QuotaPeriod = "MONTHLY"
+)
+
+// QuotaBudget is the amount of data which may be downloaded in a period,
+// either from one news-server or from all of them.
+type QuotaBudget struct {
+	// Name identifies the budget in events.
+	Name string
+
+	// ServerID is the number of the news-server the budget applies to, or 0
+	// for the total of all servers. Exhausting a server budget deactivates
+	// the server, exhausting a total budget pauses downloading.
+	ServerID int
+
+	// Period is the period the budget is counted over.
+	Period QuotaPeriod
+
+	// ResetDay is the day of the month monthly budgets start over on. 0 means
+	// the first; days past the end of a month mean its last day.
+	ResetDay int
+
+	// Limit is the amount of data which may be downloaded per period.
+	Limit Size
+}
+
+// Start returns the start of the period containing the given time, as a
+// calendar date like the points of ServerVolume.DaySeries.
+func (b QuotaBudget) Start(t time.Time) time.Time {
+	today := calendarDate(t)
+	if b.Period != QuotaMonthly {
+		return today
+	}
+	start := resetDate(today.Year(), today.Month(), b.ResetDay)
+	if start.After(today) {
+		start = resetDate(today.Year(), today.Month()-1, b.ResetDay)
+	}
+	return start
+}
+
+// End returns the start of the period following the one containing the
+// given time.
+func (b QuotaBudget) End(t time.Time) time.Time {
+	start := b.Start(t)
+	if b.Period != QuotaMonthly {
+		return start.AddDate(0, 0, 1)
+	}
+	return resetDate(start.Year(), start.Month()+1, b.ResetDay)
+}
+
+// calendarDate returns the calendar day of t as midnight UTC.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// resetDate returns the given day of the month, limited to the days the
+// month has.
+func resetDate(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day < 1 {
+		day = 1
+	}
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// QuotaUsage is the state of a budget at a point in time.
+type QuotaUsage struct {
+	// Budget is the budget the usage is for.
+	Budget QuotaBudget
+
+	// Start and End are the calendar dates the current period starts on
+	// and the next one starts on.
+	Start, End time.Time
+
+	// Used is the amount of data downloaded in the current period.
+	Used Size
+
+	// Remaining is the amount of data left in the current period.
+	Remaining Size
+
+	// Exhausted is set once nothing is left.
+	Exhausted bool
+
+	// ExhaustedAt is the projected time the budget runs out at the current
+	// download speed, or the zero time if it does not run out before the end
+	// of the period or nothing is being downloaded.
+	ExhaustedAt time.Time
+}
+
+// Usage returns the usage of the budget at the given time, counted from the
+// day series of the volumes. The projection uses the current download speed
+// of the status, which for server budgets is an upper bound as the speed is
+// shared by all servers.
+func (b QuotaBudget) Usage(now time.Time, status Status, volumes []ServerVolume) QuotaUsage {
+	usage := QuotaUsage{Budget: b, Start: b.Start(now), End: b.End(now)}
+	if volume, ok := VolumeByServer(volumes, b.ServerID); ok {
+		for _, point := range volume.DaySeries() {
+			if !point.Time.Before(usage.Start) && point.Time.Before(usage.End) {
+				usage.Used += point.Bytes
+			}
+		}
+	}
+	if usage.Used >= b.Limit {
+		usage.Exhausted = true
+		return usage
+	}
+	usage.Remaining = b.Limit - usage.Used
+	if status.DownloadRate > 0 {
+		seconds := float64(usage.Remaining) / float64(status.DownloadRate)
+		at := now.Add(time.Duration(seconds * float64(time.Second)))
+		if calendarDate(at).Before(usage.End) {
+			usage.ExhaustedAt = at
+		}
+	}
+	return usage
+}
+
+// QuotaAction is what a QuotaManager did
+type QuotaAction string
+
+// Actions of a QuotaManager
+const (
+	QuotaPaused         QuotaAction = "PAUSED"
+	QuotaResumed        QuotaAction = "RESUMED"
+	QuotaServerDisabled QuotaAction = "SERVER_DISABLED"
+	QuotaServerEnabled  QuotaAction = "SERVER_ENABLED"
+)
+
+// QuotaEvent reports an action of a QuotaManager.
+type QuotaEvent struct {
+	// Time is the time of the action.
+	Time time.Time
+
+	// Action is what the manager did.
+	Action QuotaAction
+
+	// Usage is the usage of the budget which caused the action.
+	Usage QuotaUsage
+
+	// Err is the error returned by the server when carrying out the action.
+	Err error
+}
+
+// QuotaOption configures a QuotaManager
+type QuotaOption func(*QuotaManager)
+
+// WithQuotaInterval sets how often the traffic is checked. The default is one
+// minute.
+func WithQuotaInterval(interval time.Duration) QuotaOption {
+	return func(m *QuotaManager) {
+		m.interval = interval
+	}
+}
+
+// WithQuotaClock sets the function returning the current time, whose
+// location decides the calendar day. The default is time.Now.
+func WithQuotaClock(now func() time.Time) QuotaOption {
+	return func(m *QuotaManager) {
+		m.now = now
+	}
+}
+
+// QuotaManager enforces traffic budgets. When a total budget is exhausted it
+// pauses downloading, when a server budget is exhausted it deactivates the
+// server. Once all budgets of the target have started over it undoes its own
+// action; a queue paused or a server deactivated by someone else is left
+// alone.
+type QuotaManager struct {
+	client   *NZBGet
+	budgets  []QuotaBudget
+	interval time.Duration
+	now      func() time.Time
+	events   chan QuotaEvent
+
+	// acted maps the server IDs the manager paused or deactivated, 0 for the
+	// queue, to the index of the budget which caused it.
+	acted map[int]int
+}
+
+// NewQuotaManager returns a quota manager enforcing the budgets with the
+// given client.
+func NewQuotaManager(client *NZBGet, budgets []QuotaBudget, opts ...QuotaOption) *QuotaManager {
+	m := &QuotaManager{
+		client:   client,
+		budgets:  budgets,
+		interval: time.Minute,
+		now:      time.Now,
+		events:   make(chan QuotaEvent, 100),
+		acted:    map[int]int{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Events returns the channel the actions of the manager are sent on. It is
+// closed when Run returns.
+func (m *QuotaManager) Events() <-chan QuotaEvent {
+	return m.events
+}
+
+// Usage returns the usage of all budgets, in the order they were given.
+func (m *QuotaManager) Usage(status Status, volumes []ServerVolume) []QuotaUsage {
+	return m.usage(m.now(), status, volumes)
+}
+
+func (m *QuotaManager) usage(now time.Time, status Status, volumes []ServerVolume) []QuotaUsage {
+	usages := make([]QuotaUsage, len(m.budgets))
+	for i, budget := range m.budgets {
+		usages[i] = budget.Usage(now, status, volumes)
+	}
+	return usages
+}
+
+// Run enforces the budgets until the context is done and returns the context
+// error. Budgets are not checked in rounds where the status or the volumes
+// cannot be fetched.
+func (m *QuotaManager) Run(ctx context.Context) error {
+	defer close(m.events)
+	return pollEvery(ctx, m.interval, func() {
+		events, ok := m.poll()
+		if !ok {
+			return
+		}
+		for _, event := range events {
+			select {
+			case m.events <- event:
+			default:
+			}
+		}
+	})
+}
+
+func (m *QuotaManager) poll() ([]QuotaEvent, bool) {
+	status, err := m.client.Status()
+	if err != nil {
+		return nil, false
+	}
+	volumes, err := m.client.ServerVolumes()
+	if err != nil {
+		return nil, false
+	}
+	return m.check(*status, volumes), true
+}
+
+// check compares the usage with the budgets and pauses, resumes, deactivates
+// or activates accordingly.
+func (m *QuotaManager) check(status Status, volumes []ServerVolume) []QuotaEvent {
+	now := m.now()
+	usages := m.usage(now, status, volumes)
+
+	// The first exhausted budget of each target decides.
+	exhausted := map[int]int{}
+	var targets []int
+	for i, usage := range usages {
+		id := usage.Budget.ServerID
+		if _, seen := exhausted[id]; !seen {
+			exhausted[id] = -1
+			targets = append(targets, id)
+		}
+		if usage.Exhausted && exhausted[id] < 0 {
+			exhausted[id] = i
+		}
+	}
+
+	var events []QuotaEvent
+	for _, id := range targets {
+		index := exhausted[id]
+		acted, hasActed := m.acted[id]
+		switch {
+		case index >= 0 && m.running(status, id):
+			event := QuotaEvent{Time: now, Usage: usages[index]}
+			event.Action, event.Err = m.stop(id)
+			if event.Err == nil {
+				m.acted[id] = index
+			}
+			events = append(events, event)
+		case index < 0 && hasActed:
+			event := QuotaEvent{Time: now, Usage: usages[acted]}
+			event.Action, event.Err = m.start(id)
+			if event.Err == nil {
+				delete(m.acted, id)
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// running reports whether the queue, for ID 0, or the server is active.
+func (m *QuotaManager) running(status Status, id int) bool {
+	if id == 0 {
+		return !status.DownloadPaused
+	}
+	for _, state := range status.NewsServers {
+		if state.ID == id {
+			return state.Active
+		}
+	}
+	return false
+}
+
+func (m *QuotaManager) stop(id int) (QuotaAction, error) {
+	if id == 0 {
+		return QuotaPaused, accepted(m.client.PauseDownload())
+	}
+	return QuotaServerDisabled, accepted(m.client.EditServer(id, false))
+}
+
+func (m *QuotaManager) start(id int) (QuotaAction, error) {
+	if id == 0 {
+		return QuotaResumed, accepted(m.client.ResumeDownload())
+	}
+	return QuotaServerEnabled, accepted(m.client.EditServer(id, true))
+}
@@ -0,0 +1,177 @@
+package nzbget_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// firstDay is 1 January 2024 in days since the epoch.
+const firstDay = 19723
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// dayVolume returns the volume of a server which downloaded the given number
+// of gigabytes per day from 1 January 2024 on.
+func dayVolume(serverID int, gigabytes ...int) nzbget.ServerVolume {
+	volume := nzbget.ServerVolume{ServerID: serverID, FirstDay: firstDay}
+	for _, gb := range gigabytes {
+		size := nzbget.Size(gb) * nzbget.Gigabyte
+		volume.BytesPerDays = append(volume.BytesPerDays, nzbget.ByteRate{
+			SizeHi: int(size >> 32),
+			SizeLo: int(size & 0xffffffff),
+		})
+	}
+	return volume
+}
+
+func dayVolumesJSON(volumes ...nzbget.ServerVolume) string {
+	result := `{"version": "1.1", "result": [`
+	for i, volume := range volumes {
+		if i > 0 {
+			result += ","
+		}
+		days := ""
+		for j, rate := range volume.BytesPerDays {
+			if j > 0 {
+				days += ","
+			}
+			days += fmt.Sprintf(`{"SizeHi": %d, "SizeLo": %d}`, rate.SizeHi, rate.SizeLo)
+		}
+		result += fmt.Sprintf(`{"ServerID": %d, "FirstDay": %d, "DaySlot": %d, "BytesPerDays": [%s]}`,
+			volume.ServerID, volume.FirstDay, len(volume.BytesPerDays)-1, days)
+	}
+	return result + "]}"
+}
+
+func mockQuotaPoll(status string, volumes ...nzbget.ServerVolume) {
+	gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(`{"version": "1.1", "result": ` + status + `}`)
+	gock.New(nzbgetURL).Get("/jsonrpc/servervolumes").Reply(200).JSON(dayVolumesJSON(volumes...))
+}
+
+func mockEditServer(id int, active bool) {
+	gock.New(nzbgetURL).
+		Post("/jsonrpc").
+		JSON(map[string]interface{}{"method": "editserver", "params": []interface{}{id, active}}).
+		Reply(200).
+		JSON(`{"version": "1.1", "result": true}`)
+}
+
+var _ = Describe("QuotaBudget", func() {
+
+	It("should start monthly periods on the reset day", func() {
+		budget := nzbget.QuotaBudget{Period: nzbget.QuotaMonthly, ResetDay: 15}
+		Expect(budget.Start(date(2024, time.January, 10))).To(Equal(date(2023, time.December, 15)))
+		Expect(budget.End(date(2024, time.January, 10))).To(Equal(date(2024, time.January, 15)))
+		Expect(budget.Start(date(2024, time.January, 15))).To(Equal(date(2024, time.January, 15)))
+		Expect(budget.End(date(2024, time.January, 20))).To(Equal(date(2024, time.February, 15)))
+	})
+
+	It("should limit the reset day to the length of the month", func() {
+		budget := nzbget.QuotaBudget{Period: nzbget.QuotaMonthly, ResetDay: 31}
+		Expect(budget.Start(date(2024, time.March, 1))).To(Equal(date(2024, time.February, 29)))
+		Expect(budget.End(date(2024, time.March, 1))).To(Equal(date(2024, time.March, 31)))
+	})
+
+	It("should count the days of the period", func() {
+		budget := nzbget.QuotaBudget{Period: nzbget.QuotaMonthly, ResetDay: 2, Limit: 10 * nzbget.Gigabyte}
+		volumes := []nzbget.ServerVolume{dayVolume(0, 5, 1, 2, 3)}
+		status := nzbget.Status{DownloadRate: int(nzbget.Megabyte)}
+		now := date(2024, time.January, 4).Add(12 * time.Hour)
+		usage := budget.Usage(now, status, volumes)
+		Expect(usage).To(MatchFields(IgnoreExtras, Fields{
+			"Used":      Equal(6 * nzbget.Gigabyte),
+			"Remaining": Equal(4 * nzbget.Gigabyte),
+			"Exhausted": BeFalse(),
+		}))
+		Expect(usage.ExhaustedAt).To(Equal(now.Add(4096 * time.Second)))
+	})
+
+	It("should not project beyond the period", func() {
+		budget := nzbget.QuotaBudget{Period: nzbget.QuotaDaily, Limit: 10 * nzbget.Gigabyte}
+		status := nzbget.Status{DownloadRate: int(nzbget.Kilobyte)}
+		usage := budget.Usage(date(2024, time.January, 1), status, []nzbget.ServerVolume{dayVolume(0, 1)})
+		Expect(usage.Used).To(Equal(nzbget.Gigabyte))
+		Expect(usage.ExhaustedAt).To(BeZero())
+	})
+})
+
+var _ = Describe("QuotaManager", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive())
+		gock.Off()
+	})
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	It("should stop the exhausted targets and start them after the reset", func() {
+		budgets := []nzbget.QuotaBudget{
+			{Name: "provider", ServerID: 1, Period: nzbget.QuotaDaily, Limit: 5 * nzbget.Gigabyte},
+			{Name: "total", Period: nzbget.QuotaDaily, Limit: 10 * nzbget.Gigabyte},
+		}
+		active := `{"NewsServers": [{"ID": 1, "Active": true}, {"ID": 2, "Active": true}]}`
+		stopped := `{"DownloadPaused": true, "NewsServers": [{"ID": 1, "Active": false}, {"ID": 2, "Active": true}]}`
+
+		mockQuotaPoll(active, dayVolume(0, 4), dayVolume(1, 2), dayVolume(2, 2))
+		mockQuotaPoll(active, dayVolume(0, 11), dayVolume(1, 6), dayVolume(2, 5))
+		mockEditServer(1, false)
+		gock.New(nzbgetURL).Get("/jsonrpc/pausedownload").Reply(200).JSON(`{"version": "1.1", "result": true}`)
+		mockQuotaPoll(stopped, dayVolume(0, 12), dayVolume(1, 6), dayVolume(2, 6))
+		mockQuotaPoll(stopped, dayVolume(0, 12, 0), dayVolume(1, 6, 0), dayVolume(2, 6, 0))
+		mockEditServer(1, true)
+		gock.New(nzbgetURL).Get("/jsonrpc/resumedownload").Reply(200).JSON(`{"version": "1.1", "result": true}`)
+
+		times := []time.Time{
+			date(2024, time.January, 1).Add(10 * time.Hour),
+			date(2024, time.January, 1).Add(12 * time.Hour),
+			date(2024, time.January, 1).Add(14 * time.Hour),
+			date(2024, time.January, 2).Add(time.Minute),
+		}
+		clock := func() time.Time {
+			now := times[0]
+			if len(times) > 1 {
+				times = times[1:]
+			}
+			return now
+		}
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		manager := nzbget.NewQuotaManager(client, budgets,
+			nzbget.WithQuotaInterval(time.Millisecond),
+			nzbget.WithQuotaClock(clock))
+		done = start(ctx, manager)
+
+		events := receive(manager.Events(), 4).([]nzbget.QuotaEvent)
+		Expect(events[0]).To(MatchFields(IgnoreExtras, Fields{
+			"Action": Equal(nzbget.QuotaServerDisabled),
+			"Usage": MatchFields(IgnoreExtras, Fields{
+				"Used":      Equal(6 * nzbget.Gigabyte),
+				"Exhausted": BeTrue(),
+			}),
+			"Err": BeNil(),
+		}))
+		Expect(events[1].Action).To(Equal(nzbget.QuotaPaused))
+		Expect(events[1].Usage.Budget.Name).To(Equal("total"))
+		Expect(events[2].Action).To(Equal(nzbget.QuotaServerEnabled))
+		Expect(events[2].Usage.Used).To(BeZero())
+		Expect(events[3].Action).To(Equal(nzbget.QuotaResumed))
+	})
+})
@@ -0,0 +1,372 @@
+package nzbget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RuleCondition tells whether a group matches, given the current time
+type RuleCondition func(group FileGroup, now time.Time) bool
+
+// MatchCategory matches groups in any of the categories, ignoring case.
+func MatchCategory(categories ...string) RuleCondition {
+	return func(group FileGroup, now time.Time) bool {
+		for _, category := range categories {
+			if strings.EqualFold(group.Category, category) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchName matches groups whose name matches the expression.
+func MatchName(expression *regexp.Regexp) RuleCondition {
+	return func(group FileGroup, now time.Time) bool {
+		return expression.MatchString(group.NZBName)
+	}
+}
+
+// MatchSize matches groups whose total size is at least min and, unless max
+// is 0, at most max.
+func MatchSize(min, max Size) RuleCondition {
+	return func(group FileGroup, now time.Time) bool {
+		size := group.FileSize()
+		return size >= min && (max == 0 || size <= max)
+	}
+}
+
+// MatchAge matches groups whose newest post is at least min and, unless max
+// is 0, at most max old. Groups without post time never match.
+func MatchAge(min, max time.Duration) RuleCondition {
+	return func(group FileGroup, now time.Time) bool {
+		posted := group.MaxPostTimeUTC()
+		if posted.IsZero() {
+			return false
+		}
+		age := now.Sub(posted)
+		return age >= min && (max == 0 || age <= max)
+	}
+}
+
+// MatchDupeScore matches groups whose duplicate score is between min and max
+// inclusive.
+func MatchDupeScore(min, max int) RuleCondition {
+	return func(group FileGroup, now time.Time) bool {
+		return group.DupeScore >= min && group.DupeScore <= max
+	}
+}
+
+// MatchHealthBelow matches groups whose health, in permille, is below the
+// given value.
+func MatchHealthBelow(health int) RuleCondition {
+	return func(group FileGroup, now time.Time) bool {
+		return group.Health < health
+	}
+}
+
+// RuleAction is a change of a group, carried out through EditQueue.
+type RuleAction struct {
+	Command EditCommand
+	Param   string
+}
+
+// SetPriority is the action setting the priority of the group.
+func SetPriority(priority int) RuleAction {
+	return RuleAction{EditGroupSetPriority, strconv.Itoa(priority)}
+}
+
+// SetCategory is the action setting the category of the group and applying
+// the post-processing parameters of the category.
+func SetCategory(category string) RuleAction {
+	return RuleAction{EditGroupApplyCategory, category}
+}
+
+// MoveToTop is the action moving the group to the top of the queue.
+func MoveToTop() RuleAction {
+	return RuleAction{EditGroupMoveTop, ""}
+}
+
+// MoveToBottom is the action moving the group to the bottom of the queue.
+func MoveToBottom() RuleAction {
+	return RuleAction{EditGroupMoveBottom, ""}
+}
+
+// MoveBy is the action moving the group by offset positions, towards the top
+// for negative offsets.
+func MoveBy(offset int) RuleAction {
+	return RuleAction{EditGroupMoveOffset, strconv.Itoa(offset)}
+}
+
+func (a RuleAction) String() string {
+	if a.Param == "" {
+		return string(a.Command)
+	}
+	return string(a.Command) + " " + a.Param
+}
+
+// done reports whether the action has no effect on the group. Moves are
+// never done, the engine remembers them instead.
+func (a RuleAction) done(group FileGroup) bool {
+	switch a.Command {
+	case EditGroupSetPriority:
+		return a.Param == strconv.Itoa(group.MaxPriority)
+	case EditGroupSetCategory, EditGroupApplyCategory:
+		return a.Param == group.Category
+	}
+	return false
+}
+
+// isSetting reports whether the action sets a value rather than moving the
+// group.
+func (a RuleAction) isSetting() bool {
+	switch a.Command {
+	case EditGroupSetPriority, EditGroupSetCategory, EditGroupApplyCategory:
+		return true
+	}
+	return false
+}
+
+// QueueRule applies its actions to the groups matching all its conditions.
+type QueueRule struct {
+	// Name identifies the rule in changes. Moves are remembered per group and
+	// rule name, so names should be unique.
+	Name string
+
+	// Conditions must all hold for a group to match. A rule without
+	// conditions matches every group.
+	Conditions []RuleCondition
+
+	// Actions are carried out in order on matching groups.
+	Actions []RuleAction
+}
+
+// Matches reports whether the group matches all conditions of the rule.
+func (r QueueRule) Matches(group FileGroup, now time.Time) bool {
+	for _, condition := range r.Conditions {
+		if !condition(group, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// RuleChange is a change of a group decided on by a RuleEngine.
+type RuleChange struct {
+	// Rule is the name of the rule the change is from.
+	Rule string
+
+	// NZBID and Name identify the group.
+	NZBID int
+	Name  string
+
+	// Action is the change.
+	Action RuleAction
+
+	// Planned is set if the change was only planned and not carried out.
+	Planned bool
+
+	// Err is the error returned by the server when carrying out the change.
+	Err error
+}
+
+func (c RuleChange) String() string {
+	line := fmt.Sprintf("%s: #%d %q: %s", c.Rule, c.NZBID, c.Name, c.Action)
+	if c.Err != nil {
+		line += " (failed: " + c.Err.Error() + ")"
+	}
+	return line
+}
+
+// RuleOption configures a RuleEngine
+type RuleOption func(*RuleEngine)
+
+// WithRuleInterval sets how often Run applies the rules. The default is one
+// minute.
+func WithRuleInterval(interval time.Duration) RuleOption {
+	return func(e *RuleEngine) {
+		e.interval = interval
+	}
+}
+
+// WithPlanOnly makes the engine write the changes it would make to w, one
+// per line, instead of carrying them out. As the plan stays the same until
+// the queue changes, only changes not in the previous plan are written.
+func WithPlanOnly(w io.Writer) RuleOption {
+	return func(e *RuleEngine) {
+		e.plan = w
+	}
+}
+
+// RuleEngine applies queue rules to the groups in the download queue. For
+// each group the first matching rule wins. Priority and category changes are
+// only made if the group differs; moves are made once per group and rule.
+// Groups in post-processing are left alone. Plan and Apply may be called
+// while Run is running.
+type RuleEngine struct {
+	client   *NZBGet
+	rules    []QueueRule
+	interval time.Duration
+	plan     io.Writer
+	changes  chan RuleChange
+
+	// mu guards the state kept between rounds.
+	mu      sync.Mutex
+	planned map[planKey]bool
+	moved   map[moveKey]bool
+}
+
+type moveKey struct {
+	nzbID int
+	rule  string
+}
+
+type planKey struct {
+	moveKey
+	action RuleAction
+}
+
+// NewRuleEngine returns a rule engine applying the rules with the given
+// client.
+func NewRuleEngine(client *NZBGet, rules []QueueRule, opts ...RuleOption) *RuleEngine {
+	e := &RuleEngine{
+		client:   client,
+		rules:    rules,
+		interval: time.Minute,
+		planned:  map[planKey]bool{},
+		changes:  make(chan RuleChange, 100),
+		moved:    map[moveKey]bool{},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Changes returns the channel Run sends the changes on. It is closed when Run
+// returns.
+func (e *RuleEngine) Changes() <-chan RuleChange {
+	return e.changes
+}
+
+// Plan returns the changes the rules call for on the groups at the given
+// time, without carrying them out.
+func (e *RuleEngine) Plan(groups []FileGroup, now time.Time) []RuleChange {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.changesFor(groups, now)
+}
+
+func (e *RuleEngine) changesFor(groups []FileGroup, now time.Time) []RuleChange {
+	var changes []RuleChange
+	for _, group := range groups {
+		if group.Status.IsPostProcessing() || group.Status.IsTerminal() {
+			continue
+		}
+		for _, rule := range e.rules {
+			if !rule.Matches(group, now) {
+				continue
+			}
+			moved := e.moved[moveKey{group.NZBID, rule.Name}]
+			for _, action := range rule.Actions {
+				if action.done(group) || (moved && !action.isSetting()) {
+					continue
+				}
+				changes = append(changes, RuleChange{
+					Rule:    rule.Name,
+					NZBID:   group.NZBID,
+					Name:    group.NZBName,
+					Action:  action,
+					Planned: true,
+				})
+			}
+			break
+		}
+	}
+	return changes
+}
+
+// Apply fetches the queue once and carries out the changes the rules call
+// for, or writes them in plan-only mode. It returns the changes; the errors
+// of single changes are reported in them. In plan-only mode only the changes
+// not in the previous plan are returned.
+func (e *RuleEngine) Apply() ([]RuleChange, error) {
+	groups, err := e.client.FileGroups()
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.forget(groups)
+	changes := e.changesFor(groups, time.Now())
+	if e.plan != nil {
+		return e.writePlan(changes)
+	}
+	for i := range changes {
+		change := &changes[i]
+		change.Planned = false
+		change.Err = e.client.edit(change.Action.Command, change.Action.Param, change.NZBID)
+		if change.Err == nil && !change.Action.isSetting() {
+			e.moved[moveKey{change.NZBID, change.Rule}] = true
+		}
+	}
+	return changes, nil
+}
+
+// forget drops the moves remembered for groups which left the queue.
+func (e *RuleEngine) forget(groups []FileGroup) {
+	queued := make(map[int]bool, len(groups))
+	for _, group := range groups {
+		queued[group.NZBID] = true
+	}
+	for key := range e.moved {
+		if !queued[key.nzbID] {
+			delete(e.moved, key)
+		}
+	}
+}
+
+// writePlan writes the changes which were not in the previous plan and
+// returns them.
+func (e *RuleEngine) writePlan(changes []RuleChange) ([]RuleChange, error) {
+	planned := make(map[planKey]bool, len(changes))
+	var fresh []RuleChange
+	for _, change := range changes {
+		key := planKey{moveKey{change.NZBID, change.Rule}, change.Action}
+		planned[key] = true
+		if e.planned[key] {
+			continue
+		}
+		if _, err := fmt.Fprintln(e.plan, change); err != nil {
+			return nil, err
+		}
+		fresh = append(fresh, change)
+	}
+	e.planned = planned
+	return fresh, nil
+}
+
+// Run applies the rules once per interval until the context is done and
+// returns the context error. A round in which the queue cannot be fetched
+// changes nothing.
+func (e *RuleEngine) Run(ctx context.Context) error {
+	defer close(e.changes)
+	return pollEvery(ctx, e.interval, func() {
+		changes, err := e.Apply()
+		if err != nil {
+			return
+		}
+		for _, change := range changes {
+			select {
+			case e.changes <- change:
+			default:
+			}
+		}
+	})
+}
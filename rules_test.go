@@ -0,0 +1,179 @@
+package nzbget_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const ruleGroups = `{"version": "1.1", "result": [
+  {"NZBID": 1, "NZBName": "Linux.ISO.x64", "Category": "Software", "Status": "QUEUED", "MaxPriority": 0, "FileSizeLo": 1048576},
+  {"NZBID": 2, "NZBName": "Holiday.Photos", "Category": "", "Status": "QUEUED", "MaxPriority": 100, "FileSizeHi": 2},
+  {"NZBID": 3, "NZBName": "Linux.Source", "Category": "Software", "Status": "UNPACKING", "MaxPriority": 0}
+]}`
+
+var _ = Describe("Queue rules", func() {
+	now := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	Context("conditions", func() {
+		group := nzbget.FileGroup{
+			NZBName:     "Linux.ISO.x64",
+			Category:    "Software",
+			FileSizeHi:  1,
+			MaxPostTime: int(now.Add(-48 * time.Hour).Unix()),
+			DupeScore:   10,
+			Health:      950,
+		}
+
+		It("should match the category ignoring case", func() {
+			Expect(nzbget.MatchCategory("movies", "software")(group, now)).To(BeTrue())
+			Expect(nzbget.MatchCategory("movies")(group, now)).To(BeFalse())
+		})
+
+		It("should match the name", func() {
+			Expect(nzbget.MatchName(regexp.MustCompile(`(?i)^linux\.`))(group, now)).To(BeTrue())
+			Expect(nzbget.MatchName(regexp.MustCompile(`x86`))(group, now)).To(BeFalse())
+		})
+
+		It("should match the size", func() {
+			Expect(nzbget.MatchSize(nzbget.Gigabyte, 0)(group, now)).To(BeTrue())
+			Expect(nzbget.MatchSize(0, nzbget.Gigabyte)(group, now)).To(BeFalse())
+		})
+
+		It("should match the age", func() {
+			Expect(nzbget.MatchAge(24*time.Hour, 72*time.Hour)(group, now)).To(BeTrue())
+			Expect(nzbget.MatchAge(72*time.Hour, 0)(group, now)).To(BeFalse())
+			Expect(nzbget.MatchAge(0, 0)(nzbget.FileGroup{}, now)).To(BeFalse())
+		})
+
+		It("should match the dupe score and health", func() {
+			Expect(nzbget.MatchDupeScore(0, 10)(group, now)).To(BeTrue())
+			Expect(nzbget.MatchDupeScore(11, 100)(group, now)).To(BeFalse())
+			Expect(nzbget.MatchHealthBelow(1000)(group, now)).To(BeTrue())
+			Expect(nzbget.MatchHealthBelow(900)(group, now)).To(BeFalse())
+		})
+	})
+
+	Context("engine", func() {
+		rules := []nzbget.QueueRule{
+			{
+				Name:       "software first",
+				Conditions: []nzbget.RuleCondition{nzbget.MatchCategory("software")},
+				Actions:    []nzbget.RuleAction{nzbget.SetPriority(nzbget.PriorityHigh), nzbget.MoveToTop()},
+			},
+			{
+				Name:       "large photos",
+				Conditions: []nzbget.RuleCondition{nzbget.MatchSize(nzbget.Gigabyte, 0)},
+				Actions:    []nzbget.RuleAction{nzbget.SetPriority(nzbget.PriorityVeryHigh), nzbget.SetCategory("Photos")},
+			},
+		}
+
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		It("should only write the plan in plan-only mode", func() {
+			mockGroups(ruleGroups)
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			var plan bytes.Buffer
+			engine := nzbget.NewRuleEngine(client, rules, nzbget.WithPlanOnly(&plan))
+			changes, err := engine.Apply()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(3))
+			Expect(changes[0].Planned).To(BeTrue())
+			Expect(plan.String()).To(Equal(`software first: #1 "Linux.ISO.x64": GroupSetPriority 50
+software first: #1 "Linux.ISO.x64": GroupMoveTop
+large photos: #2 "Holiday.Photos": GroupApplyCategory Photos
+`))
+		})
+
+		It("should only write the changes new since the last plan", func() {
+			mockGroups(ruleGroups)
+			mockGroups(ruleGroups)
+			mockGroups(`{"version": "1.1", "result": [
+  {"NZBID": 1, "NZBName": "Linux.ISO.x64", "Category": "Software", "Status": "QUEUED", "MaxPriority": 0},
+  {"NZBID": 4, "NZBName": "Linux.Kernel", "Category": "Software", "Status": "QUEUED", "MaxPriority": 50}
+]}`)
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			var plan bytes.Buffer
+			engine := nzbget.NewRuleEngine(client, rules, nzbget.WithPlanOnly(&plan))
+			_, err = engine.Apply()
+			Expect(err).ToNot(HaveOccurred())
+			plan.Reset()
+
+			changes, err := engine.Apply()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+			Expect(plan.String()).To(BeEmpty())
+
+			changes, err = engine.Apply()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(1))
+			Expect(plan.String()).To(Equal(`software first: #4 "Linux.Kernel": GroupMoveTop
+`))
+		})
+
+		It("should carry out the changes and move only once", func() {
+			mockGroups(ruleGroups)
+			mockEdit(nzbget.EditGroupSetPriority, "50", []int{1}, true)
+			mockEdit(nzbget.EditGroupMoveTop, "", []int{1}, true)
+			mockEdit(nzbget.EditGroupApplyCategory, "Photos", []int{2}, false)
+			mockGroups(ruleGroups)
+			mockEdit(nzbget.EditGroupSetPriority, "50", []int{1}, true)
+			mockEdit(nzbget.EditGroupApplyCategory, "Photos", []int{2}, true)
+
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			engine := nzbget.NewRuleEngine(client, rules)
+			changes, err := engine.Apply()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(3))
+			Expect(changes[2]).To(MatchFields(IgnoreExtras, Fields{
+				"Rule":    Equal("large photos"),
+				"NZBID":   Equal(2),
+				"Planned": BeFalse(),
+				"Err":     Equal(nzbget.ErrEditRejected),
+			}))
+
+			changes, err = engine.Apply()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[0].Action).To(Equal(nzbget.SetPriority(50)))
+			Expect(changes[1].Err).ToNot(HaveOccurred())
+			Expect(gock.IsDone()).To(BeTrue())
+		})
+
+		It("should plan while running", func() {
+			mockGroups(ruleGroups)
+			mockEdit(nzbget.EditGroupSetPriority, "50", []int{1}, true)
+			mockEdit(nzbget.EditGroupMoveTop, "", []int{1}, true)
+			mockEdit(nzbget.EditGroupApplyCategory, "Photos", []int{2}, true)
+			mockGroups(ruleGroups)
+			mockEdit(nzbget.EditGroupSetPriority, "50", []int{1}, true)
+			mockEdit(nzbget.EditGroupApplyCategory, "Photos", []int{2}, true)
+
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			engine := nzbget.NewRuleEngine(client, rules, nzbget.WithRuleInterval(time.Millisecond))
+			ctx, cancel := context.WithCancel(context.Background())
+			done := start(ctx, engine)
+			group := nzbget.FileGroup{NZBID: 1, Category: "Software", Status: nzbget.GroupStatusQueued}
+			Eventually(func() bool {
+				engine.Plan([]nzbget.FileGroup{group}, now)
+				return gock.IsDone()
+			}).Should(BeTrue())
+			cancel()
+			Eventually(done).Should(Receive())
+			Expect(engine.Plan([]nzbget.FileGroup{group}, now)).To(HaveLen(1))
+		})
+	})
+})
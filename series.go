@@ -0,0 +1,83 @@
+package nzbget
+
+import "time"
+
+// Point is the amount of data downloaded in one slot of a volume time series.
+type Point struct {
+	// Time is the start of the slot, in UTC.
+	Time time.Time
+
+	// Bytes is the amount of data downloaded within the slot.
+	Bytes Size
+}
+
+// SecondSeries returns the data downloaded per second during the last minute
+// in chronological order, the last point being the second of DataTime.
+func (v ServerVolume) SecondSeries() []Point {
+	return v.unroll(v.BytesPerSeconds, v.SecSlot, time.Second)
+}
+
+// MinuteSeries returns the data downloaded per minute during the last hour in
+// chronological order, the last point being the minute of DataTime.
+func (v ServerVolume) MinuteSeries() []Point {
+	return v.unroll(v.BytesPerMinutes, v.MinSlot, time.Minute)
+}
+
+// HourSeries returns the data downloaded per hour during the last day in
+// chronological order, the last point being the hour of DataTime.
+func (v ServerVolume) HourSeries() []Point {
+	return v.unroll(v.BytesPerHours, v.HourSlot, time.Hour)
+}
+
+// DaySeries returns the data downloaded per day since program installation in
+// chronological order, the first point being FirstDay. Days are calendar days
+// of the server, see FirstDayUTC.
+func (v ServerVolume) DaySeries() []Point {
+	first := v.FirstDayUTC()
+	if first.IsZero() {
+		first = unixTime(0)
+	}
+	points := make([]Point, len(v.BytesPerDays))
+	for i, rate := range v.BytesPerDays {
+		points[i] = Point{Time: first.AddDate(0, 0, i), Bytes: rate.Size()}
+	}
+	return points
+}
+
+// unroll orders the circular buffer rates whose slot current is being written
+// into at DataTime. The slot after the current one holds the oldest data.
+func (v ServerVolume) unroll(rates []ByteRate, current int, unit time.Duration) []Point {
+	n := len(rates)
+	if n == 0 {
+		return nil
+	}
+	if current < 0 || current >= n {
+		current = n - 1
+	}
+	last := v.DataTimeUTC().Truncate(unit)
+	points := make([]Point, n)
+	for k := 0; k < n; k++ {
+		rate := rates[(current+1+k)%n]
+		points[k] = Point{
+			Time:  last.Add(-time.Duration(n-1-k) * unit),
+			Bytes: rate.Size(),
+		}
+	}
+	return points
+}
+
+// VolumeByServer returns the volume statistics of the news-server with the
+// given ID. ID 0 holds the totals of all servers.
+func VolumeByServer(volumes []ServerVolume, serverID int) (ServerVolume, bool) {
+	for _, volume := range volumes {
+		if volume.ServerID == serverID {
+			return volume, true
+		}
+	}
+	return ServerVolume{}, false
+}
+
+// TotalVolume returns the volume statistics summed over all news-servers.
+func TotalVolume(volumes []ServerVolume) (ServerVolume, bool) {
+	return VolumeByServer(volumes, 0)
+}
@@ -0,0 +1,108 @@
+package nzbget_test
+
+import (
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// rates returns byte rates with the given sizes in bytes.
+func rates(sizes ...int) []nzbget.ByteRate {
+	result := make([]nzbget.ByteRate, len(sizes))
+	for i, size := range sizes {
+		result[i] = nzbget.ByteRate{SizeLo: size}
+	}
+	return result
+}
+
+// bytesOf returns the amounts of the points.
+func bytesOf(points []nzbget.Point) []uint64 {
+	result := make([]uint64, len(points))
+	for i, point := range points {
+		result[i] = point.Bytes.Bytes()
+	}
+	return result
+}
+
+var _ = Describe("Series", func() {
+	var volume nzbget.ServerVolume
+
+	BeforeEach(func() {
+		volume = nzbget.ServerVolume{
+			// 2020-05-15 03:44:32 UTC
+			DataTime:        1589514272,
+			FirstDay:        18395,
+			SecSlot:         32 % 4,
+			MinSlot:         1,
+			HourSlot:        3,
+			DaySlot:         2,
+			BytesPerSeconds: rates(10, 20, 30, 40),
+			BytesPerMinutes: rates(100, 200, 300),
+			BytesPerHours:   rates(1, 2, 3, 4),
+			BytesPerDays:    rates(7, 8, 9),
+		}
+	})
+
+	It("should unroll the second slots", func() {
+		points := volume.SecondSeries()
+		Expect(bytesOf(points)).To(Equal([]uint64{20, 30, 40, 10}))
+		Expect(points[3].Time).To(Equal(time.Date(2020, 5, 15, 3, 44, 32, 0, time.UTC)))
+		Expect(points[0].Time).To(Equal(time.Date(2020, 5, 15, 3, 44, 29, 0, time.UTC)))
+	})
+
+	It("should unroll the minute slots", func() {
+		points := volume.MinuteSeries()
+		Expect(bytesOf(points)).To(Equal([]uint64{300, 100, 200}))
+		Expect(points[2].Time).To(Equal(time.Date(2020, 5, 15, 3, 44, 0, 0, time.UTC)))
+		Expect(points[0].Time).To(Equal(time.Date(2020, 5, 15, 3, 42, 0, 0, time.UTC)))
+	})
+
+	It("should keep the hour slots in order when the current slot is the last", func() {
+		points := volume.HourSeries()
+		Expect(bytesOf(points)).To(Equal([]uint64{1, 2, 3, 4}))
+		Expect(points[3].Time).To(Equal(time.Date(2020, 5, 15, 3, 0, 0, 0, time.UTC)))
+	})
+
+	It("should anchor the day slots on the first day", func() {
+		points := volume.DaySeries()
+		Expect(bytesOf(points)).To(Equal([]uint64{7, 8, 9}))
+		Expect(points[0].Time).To(Equal(time.Date(2020, 5, 13, 0, 0, 0, 0, time.UTC)))
+		Expect(points[2].Time).To(Equal(time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should return empty series without data", func() {
+		Expect(nzbget.ServerVolume{}.SecondSeries()).To(BeEmpty())
+		Expect(nzbget.ServerVolume{}.DaySeries()).To(BeEmpty())
+	})
+
+	Context("server volumes", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/servervolumes").
+				Reply(200).
+				JSON(serverVolumes)
+		})
+
+		It("should select the total and per-server volumes", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			volumes, err := client.ServerVolumes()
+			Expect(err).ToNot(HaveOccurred())
+			total, ok := nzbget.TotalVolume(volumes)
+			Expect(ok).To(BeTrue())
+			Expect(total.ServerID).To(Equal(0))
+			server, ok := nzbget.VolumeByServer(volumes, 1)
+			Expect(ok).To(BeTrue())
+			Expect(server.HourSeries()).To(HaveLen(10))
+			_, ok = nzbget.VolumeByServer(volumes, 7)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
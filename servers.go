@@ -0,0 +1,227 @@
+package nzbget
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// ServerStat is the download statistics of a group on one news-server.
+type ServerStat struct {
+	// ServerID is the server number as defined in section “news servers” of
+	// the configuration file.
+	ServerID int `json:"ServerID"`
+
+	// SuccessArticles is the number of successfully downloaded articles.
+	SuccessArticles int `json:"SuccessArticles"`
+
+	// FailedArticles is the number of failed articles.
+	FailedArticles int `json:"FailedArticles"`
+}
+
+// ServerState is the runtime state of a news-server.
+type ServerState struct {
+	// Active is true if server is in active state (enabled).
+	Active bool `json:"Active"`
+
+	// ID is the server number in the configuration file
+	ID int `json:"ID"`
+}
+
+// NewsServer is a news-server as defined in section “news servers” of the
+// configuration file. Credentials are not included.
+type NewsServer struct {
+	// ID is the server number N of the options ServerN.*.
+	ID int
+
+	// Name is the name of the server used in logs and statistics.
+	Name string
+
+	// Host is the host name or IP address of the server.
+	Host string
+
+	// Port is the port the server is connected on.
+	Port int
+
+	// Level is the level of the server. Servers with level 0 are used first,
+	// servers of higher levels are used to fill articles missing on lower
+	// levels.
+	Level int
+
+	// Group is the group of the server. Servers of the same group are used
+	// alternatively, 0 means no group.
+	Group int
+
+	// Active is true if the server is enabled. When the server directory is
+	// joined with Status, this is the runtime state instead of the
+	// configured one.
+	Active bool
+
+	// Optional is true if the server is optional, i.e. it is not considered
+	// required for downloading articles.
+	Optional bool
+
+	// Encryption is true if the connection to the server is encrypted.
+	Encryption bool
+
+	// Connections is the maximum number of simultaneous connections.
+	Connections int
+
+	// Retention is the retention time of the server in days, 0 if unlimited.
+	Retention int
+}
+
+// DisplayName returns the name of the server ready for user-friendly output:
+// Name if set, otherwise Host, otherwise “Server N”.
+func (s NewsServer) DisplayName() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Host != "":
+		return s.Host
+	}
+	return fmt.Sprintf("Server %d", s.ID)
+}
+
+// ServerDirectory resolves news-server numbers, as found in ServerVolume,
+// ServerStat and ServerState, into the configured news-servers.
+type ServerDirectory map[int]NewsServer
+
+var serverOption = regexp.MustCompile(`^Server(\d+)\.(\w+)$`)
+
+// ParseServerDirectory extracts the news-servers from a server configuration
+// as returned by Config.
+func ParseServerDirectory(config map[string]string) ServerDirectory {
+	directory := ServerDirectory{}
+	for name, value := range config {
+		match := serverOption.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		id, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		server := directory[id]
+		server.ID = id
+		switch match[2] {
+		case "Name":
+			server.Name = value
+		case "Host":
+			server.Host = value
+		case "Port":
+			server.Port, _ = strconv.Atoi(value)
+		case "Level":
+			server.Level, _ = strconv.Atoi(value)
+		case "Group":
+			server.Group, _ = strconv.Atoi(value)
+		case "Active":
+			server.Active = isYes(value)
+		case "Optional":
+			server.Optional = isYes(value)
+		case "Encryption":
+			server.Encryption = isYes(value)
+		case "Connections":
+			server.Connections, _ = strconv.Atoi(value)
+		case "Retention":
+			server.Retention, _ = strconv.Atoi(value)
+		}
+		directory[id] = server
+	}
+	return directory
+}
+
+// ServerDirectory returns the news-servers of the server configuration
+func (n NZBGet) ServerDirectory() (ServerDirectory, error) {
+	config, err := n.Config()
+	if err != nil {
+		return nil, err
+	}
+	return ParseServerDirectory(config), nil
+}
+
+// EditServer activates or deactivates the news-server with the given number
+// until the next restart or reload of the server. The configuration is not
+// changed.
+func (n NZBGet) EditServer(id int, active bool) (bool, error) {
+	var ok bool
+	err := n.call("editserver", &ok, id, active)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// Lookup returns the news-server with the given number. Unknown numbers
+// result in a server with only the ID set. ID 0 stands for the totals of all
+// servers in ServerVolumes and is named “All servers”.
+func (d ServerDirectory) Lookup(id int) NewsServer {
+	if server, ok := d[id]; ok {
+		return server
+	}
+	if id == 0 {
+		return NewsServer{Name: "All servers"}
+	}
+	return NewsServer{ID: id}
+}
+
+// Servers returns the news-servers ordered by ID.
+func (d ServerDirectory) Servers() []NewsServer {
+	servers := make([]NewsServer, 0, len(d))
+	for _, server := range d {
+		servers = append(servers, server)
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].ID < servers[j].ID
+	})
+	return servers
+}
+
+// WithStates returns a copy of the directory with Active set to the runtime
+// state reported by Status.NewsServers.
+func (d ServerDirectory) WithStates(states []ServerState) ServerDirectory {
+	result := make(ServerDirectory, len(d))
+	for id, server := range d {
+		result[id] = server
+	}
+	for _, state := range states {
+		server := result.Lookup(state.ID)
+		server.Active = state.Active
+		result[state.ID] = server
+	}
+	return result
+}
+
+// NamedServerVolume is the volume statistics of a news-server together with
+// the server.
+type NamedServerVolume struct {
+	NewsServer
+	Volume ServerVolume
+}
+
+// Volumes joins the volume statistics with the news-servers they belong to.
+func (d ServerDirectory) Volumes(volumes []ServerVolume) []NamedServerVolume {
+	result := make([]NamedServerVolume, len(volumes))
+	for i, volume := range volumes {
+		result[i] = NamedServerVolume{NewsServer: d.Lookup(volume.ServerID), Volume: volume}
+	}
+	return result
+}
+
+// NamedServerStat is the download statistics of a group on a news-server
+// together with the server.
+type NamedServerStat struct {
+	NewsServer
+	Stat ServerStat
+}
+
+// Stats joins the per-server statistics of a group with the news-servers they
+// belong to.
+func (d ServerDirectory) Stats(stats []ServerStat) []NamedServerStat {
+	result := make([]NamedServerStat, len(stats))
+	for i, stat := range stats {
+		result[i] = NamedServerStat{NewsServer: d.Lookup(stat.ServerID), Stat: stat}
+	}
+	return result
+}
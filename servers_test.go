@@ -0,0 +1,90 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("ServerDirectory", func() {
+
+	It("should parse the news-servers of a configuration", func() {
+		directory := nzbget.ParseServerDirectory(map[string]string{
+			"Server1.Name":   "Primary",
+			"Server1.Host":   "news.example.com",
+			"Server1.Level":  "0",
+			"Server1.Active": "yes",
+			"Server2.Host":   "backup.example.com",
+			"Server2.Level":  "1",
+			"Server2.Active": "no",
+			"Server3.Port":   "563",
+			"ServerNotes":    "ignored",
+			"Category1.Name": "Movies",
+		})
+		servers := directory.Servers()
+		Expect(servers).To(HaveLen(3))
+		Expect(servers[0]).To(Equal(nzbget.NewsServer{ID: 1, Name: "Primary", Host: "news.example.com", Active: true}))
+		Expect(servers[1].DisplayName()).To(Equal("backup.example.com"))
+		Expect(servers[1].Level).To(Equal(1))
+		Expect(servers[2].DisplayName()).To(Equal("Server 3"))
+		Expect(directory.Lookup(0).DisplayName()).To(Equal("All servers"))
+		Expect(directory.Lookup(9).DisplayName()).To(Equal("Server 9"))
+	})
+
+	It("should join per-server statistics", func() {
+		directory := nzbget.ServerDirectory{1: {ID: 1, Name: "Primary"}}
+		stats := directory.Stats([]nzbget.ServerStat{{ServerID: 1, SuccessArticles: 49}, {ServerID: 2, FailedArticles: 3}})
+		Expect(stats[0].DisplayName()).To(Equal("Primary"))
+		Expect(stats[0].Stat.SuccessArticles).To(Equal(49))
+		Expect(stats[1].DisplayName()).To(Equal("Server 2"))
+	})
+
+	It("should apply the runtime states", func() {
+		directory := nzbget.ServerDirectory{1: {ID: 1, Name: "Primary", Active: true}}
+		joined := directory.WithStates([]nzbget.ServerState{{ID: 1, Active: false}, {ID: 2, Active: true}})
+		Expect(joined[1].Active).To(BeFalse())
+		Expect(joined[2].Active).To(BeTrue())
+		Expect(directory[1].Active).To(BeTrue())
+	})
+
+	Context("#ServerDirectory", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/config").
+				Reply(200).
+				JSON(config)
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/servervolumes").
+				Reply(200).
+				JSON(serverVolumes)
+		})
+
+		It("should resolve server volumes to names", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			directory, err := client.ServerDirectory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(directory[1]).To(Equal(nzbget.NewsServer{
+				ID:          1,
+				Name:        "news.newsgroup.ninja",
+				Host:        "my.newserver.com",
+				Port:        443,
+				Active:      true,
+				Encryption:  true,
+				Connections: 50,
+				Retention:   4049,
+			}))
+			volumes, err := client.ServerVolumes()
+			Expect(err).ToNot(HaveOccurred())
+			named := directory.Volumes(volumes)
+			Expect(named[0].DisplayName()).To(Equal("All servers"))
+			Expect(named[1].DisplayName()).To(Equal("news.newsgroup.ninja"))
+			Expect(named[1].Volume.ServerID).To(Equal(1))
+		})
+	})
+})
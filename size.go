@@ -0,0 +1,154 @@
+package nzbget
+
+import "fmt"
+
+// Size is an amount of data in bytes. NZBGet reports 64-bit sizes as pairs of
+// 32-bit values (the ...Hi and ...Lo fields); the accessor methods of the
+// response types reassemble them into a Size.
+type Size uint64
+
+// Units of Size. As in NZBGet, a kilobyte is 1024 bytes.
+const (
+	Byte     Size = 1
+	Kilobyte      = 1024 * Byte
+	Megabyte      = 1024 * Kilobyte
+	Gigabyte      = 1024 * Megabyte
+	Terabyte      = 1024 * Gigabyte
+)
+
+// SizeFromHiLo combines the high and low 32-bit halves of a 64-bit size. Both
+// halves are unsigned on the wire, but servers writing them as signed 32-bit
+// integers send values of 2 GB and above as negative numbers; those are
+// reinterpreted as the unsigned value they encode.
+func SizeFromHiLo(hi, lo int) Size {
+	return Size(uint64(uint32(hi))<<32 | uint64(uint32(lo)))
+}
+
+// Bytes returns the size in bytes.
+func (s Size) Bytes() uint64 {
+	return uint64(s)
+}
+
+// KB returns the size in kilobytes.
+func (s Size) KB() float64 {
+	return float64(s) / float64(Kilobyte)
+}
+
+// MB returns the size in megabytes.
+func (s Size) MB() float64 {
+	return float64(s) / float64(Megabyte)
+}
+
+// GB returns the size in gigabytes.
+func (s Size) GB() float64 {
+	return float64(s) / float64(Gigabyte)
+}
+
+// TB returns the size in terabytes.
+func (s Size) TB() float64 {
+	return float64(s) / float64(Terabyte)
+}
+
+// String formats the size with the largest unit that keeps the value at or
+// above one, e.g. “512 B”, “1.50 MB” or “3.21 TB”.
+func (s Size) String() string {
+	switch {
+	case s >= Terabyte:
+		return fmt.Sprintf("%.2f TB", s.TB())
+	case s >= Gigabyte:
+		return fmt.Sprintf("%.2f GB", s.GB())
+	case s >= Megabyte:
+		return fmt.Sprintf("%.2f MB", s.MB())
+	case s >= Kilobyte:
+		return fmt.Sprintf("%.2f KB", s.KB())
+	}
+	return fmt.Sprintf("%d B", uint64(s))
+}
+
+// DownloadedSize returns the amount of downloaded data for the group.
+func (f FileGroup) DownloadedSize() Size {
+	return SizeFromHiLo(f.DownloadedSizeHi, f.DownloadedSizeLo)
+}
+
+// FileSize returns the initial size of all files in the group.
+func (f FileGroup) FileSize() Size {
+	return SizeFromHiLo(f.FileSizeHi, f.FileSizeLo)
+}
+
+// PausedSize returns the size of all paused files in the group.
+func (f FileGroup) PausedSize() Size {
+	return SizeFromHiLo(f.PausedSizeHi, f.PausedSizeLo)
+}
+
+// RemainingSize returns the remaining size of all files in the group.
+func (f FileGroup) RemainingSize() Size {
+	return SizeFromHiLo(f.RemainingSizeHi, f.RemainingSizeLo)
+}
+
+// ArticleCache returns the current usage of the article cache.
+func (s Status) ArticleCache() Size {
+	return SizeFromHiLo(s.ArticleCacheHi, s.ArticleCacheLo)
+}
+
+// DaySize returns the amount of data downloaded since the start of the day.
+func (s Status) DaySize() Size {
+	return SizeFromHiLo(s.DaySizeHi, s.DaySizeLo)
+}
+
+// DownloadedSize returns the amount of data downloaded since server start.
+func (s Status) DownloadedSize() Size {
+	return SizeFromHiLo(s.DownloadedSizeHi, s.DownloadedSizeLo)
+}
+
+// ForcedSize returns the remaining size of entries with FORCE priority.
+func (s Status) ForcedSize() Size {
+	return SizeFromHiLo(s.ForcedSizeHi, s.ForcedSizeLo)
+}
+
+// FreeDiskSpace returns the free disk space on DestDir.
+func (s Status) FreeDiskSpace() Size {
+	return SizeFromHiLo(s.FreeDiskSpaceHi, s.FreeDiskSpaceLo)
+}
+
+// MonthSize returns the amount of data downloaded since the start of the
+// month.
+func (s Status) MonthSize() Size {
+	return SizeFromHiLo(s.MonthSizeHi, s.MonthSizeLo)
+}
+
+// RemainingSize returns the remaining size of all entries in the download
+// queue.
+func (s Status) RemainingSize() Size {
+	return SizeFromHiLo(s.RemainingSizeHi, s.RemainingSizeLo)
+}
+
+// DownloadedSize returns the amount of downloaded data for the entry.
+func (h HistoricalEntry) DownloadedSize() Size {
+	return SizeFromHiLo(h.DownloadedSizeHi, h.DownloadedSizeLo)
+}
+
+// FileSize returns the initial size of all files of the entry.
+func (h HistoricalEntry) FileSize() Size {
+	return SizeFromHiLo(h.FileSizeHi, h.FileSizeLo)
+}
+
+// Size returns the amount of data transferred in the slot.
+func (b ByteRate) Size() Size {
+	return SizeFromHiLo(b.SizeHi, b.SizeLo)
+}
+
+// TotalSize returns the amount of data downloaded since program installation.
+func (v ServerVolume) TotalSize() Size {
+	return SizeFromHiLo(v.TotalSizeHi, v.TotalSizeLo)
+}
+
+// CustomSize returns the amount of data downloaded since the last reset of
+// the custom counter.
+func (v ServerVolume) CustomSize() Size {
+	return SizeFromHiLo(v.CustomSizeHi, v.CustomSizeLo)
+}
+
+// Size returns the size of the feed item.
+func (f FeedItem) Size() Size {
+	return SizeFromHiLo(f.SizeHi, f.SizeLo)
+}
@@ -0,0 +1,59 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Size", func() {
+
+	DescribeTable("#SizeFromHiLo",
+		func(hi, lo int, expected uint64) {
+			Expect(nzbget.SizeFromHiLo(hi, lo).Bytes()).To(Equal(expected))
+		},
+		Entry("small value", 0, 31871884, uint64(31871884)),
+		Entry("high part", 1022, 1309999104, uint64(1022)<<32+1309999104),
+		Entry("low part above 2 GB", 47, 2286421136, uint64(47)<<32+2286421136),
+		Entry("low part sent as negative number", 47, 2286421136-1<<32, uint64(47)<<32+2286421136),
+		Entry("high part sent as negative number", -1, -1, uint64(1<<64-1)),
+	)
+
+	DescribeTable("#String",
+		func(size nzbget.Size, expected string) {
+			Expect(size.String()).To(Equal(expected))
+		},
+		Entry("bytes", nzbget.Size(512), "512 B"),
+		Entry("kilobytes", 2*nzbget.Kilobyte, "2.00 KB"),
+		Entry("megabytes", 1536*nzbget.Kilobyte, "1.50 MB"),
+		Entry("gigabytes", 30*nzbget.Gigabyte, "30.00 GB"),
+		Entry("terabytes", 4*nzbget.Terabyte+nzbget.Terabyte/4, "4.25 TB"),
+	)
+
+	Context("accessors", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/status").
+				MatchParams(map[string]string{}).
+				Reply(200).
+				JSON(status)
+		})
+
+		It("should reassemble the status sizes", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			status, err := client.Status()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.FreeDiskSpace().MB()).To(BeNumerically("~", status.FreeDiskSpaceMB, 1))
+			Expect(status.MonthSize().MB()).To(BeNumerically("~", status.MonthSizeMB, 1))
+			Expect(status.DaySize().MB()).To(BeNumerically("~", status.DaySizeMB, 1))
+			Expect(status.RemainingSize()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,282 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// StallReason is why a group is considered stalled
+type StallReason string
+
+// Reasons of stalls
+const (
+	// StallDownload means the remaining size of a downloading group did not
+	// go down.
+	StallDownload StallReason = "DOWNLOAD"
+
+	// StallPostProcessing means the progress of the post-processing stage
+	// of a group did not move.
+	StallPostProcessing StallReason = "POST_PROCESSING"
+)
+
+// StallStep is a recovery step for a stalled group
+type StallStep string
+
+// Recovery steps
+const (
+	// StallStepPauseResume pauses and immediately resumes the group, making
+	// the server reconnect for its articles. Download stalls only.
+	StallStepPauseResume StallStep = "PAUSE_RESUME"
+
+	// StallStepMoveDown moves the group to the bottom of the queue so the
+	// other groups are downloaded first. Download stalls only.
+	StallStepMoveDown StallStep = "MOVE_DOWN"
+
+	// StallStepToHistory moves the group to history from where it can be
+	// retried: downloads are parked (GroupParkDelete), post-processing is
+	// cancelled (PostDelete).
+	StallStepToHistory StallStep = "TO_HISTORY"
+)
+
+// StallReport is a stalled group detected by a StallDetector.
+type StallReport struct {
+	// Time is the time of the detection.
+	Time time.Time
+
+	// NZBID and Name identify the group.
+	NZBID int
+	Name  string
+
+	// Status is the status of the group.
+	Status GroupStatus
+
+	// Reason is why the group is considered stalled.
+	Reason StallReason
+
+	// Since is the time the group was last seen making progress.
+	Since time.Time
+
+	// Step is the recovery step carried out, or empty if none.
+	Step StallStep
+
+	// Err is the error returned by the server when carrying out the step.
+	Err error
+}
+
+// StallOption configures a StallDetector
+type StallOption func(*StallDetector)
+
+// WithStallInterval sets how often the queue is checked. The default is one
+// minute.
+func WithStallInterval(interval time.Duration) StallOption {
+	return func(d *StallDetector) {
+		d.interval = interval
+	}
+}
+
+// WithStallWindow sets how long a group may go without progress before it is
+// reported. The default is ten minutes.
+func WithStallWindow(window time.Duration) StallOption {
+	return func(d *StallDetector) {
+		d.window = window
+	}
+}
+
+// WithStallRecovery sets the recovery steps for stalls of the given reason.
+// The first step is carried out when the stall is detected, each further
+// step after another window without progress. Steps for download stalls only
+// are dropped from the steps for post-processing stalls. By default stalls
+// are only reported.
+func WithStallRecovery(reason StallReason, steps ...StallStep) StallOption {
+	return func(d *StallDetector) {
+		var applicable []StallStep
+		for _, step := range steps {
+			if reason == StallPostProcessing && step != StallStepToHistory {
+				continue
+			}
+			applicable = append(applicable, step)
+		}
+		d.steps[reason] = applicable
+	}
+}
+
+// WithStallClock sets the function returning the current time. The default
+// is time.Now.
+func WithStallClock(now func() time.Time) StallOption {
+	return func(d *StallDetector) {
+		d.now = now
+	}
+}
+
+// StallDetector tracks the progress of the groups in the queue and reports
+// the groups which made none for a window: downloading groups whose remaining
+// size does not go down and groups in a post-processing stage whose progress
+// does not move. Waiting groups, queued or paused, are not considered; a
+// group a recovery step queued or paused keeps its escalation, though the
+// time it waits does not count as time without progress.
+type StallDetector struct {
+	client   *NZBGet
+	interval time.Duration
+	window   time.Duration
+	steps    map[StallReason][]StallStep
+	now      func() time.Time
+	reports  chan StallReport
+	states   map[int]stallState
+}
+
+// stallState is the progress of a group when it last moved.
+type stallState struct {
+	status       GroupStatus
+	remaining    Size
+	postProgress int
+	since        time.Time
+
+	// last is the time of the last check and waited the time the group spent
+	// waiting in the queue since a recovery step, which does not count as
+	// time without progress.
+	last   time.Time
+	waited time.Duration
+
+	reported int
+}
+
+// NewStallDetector returns a stall detector for the given client.
+func NewStallDetector(client *NZBGet, opts ...StallOption) *StallDetector {
+	d := &StallDetector{
+		client:   client,
+		interval: time.Minute,
+		window:   10 * time.Minute,
+		steps:    map[StallReason][]StallStep{},
+		now:      time.Now,
+		reports:  make(chan StallReport, 100),
+		states:   map[int]stallState{},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Reports returns the channel the stalled groups are reported on. It is
+// closed when Run returns.
+func (d *StallDetector) Reports() <-chan StallReport {
+	return d.reports
+}
+
+// Run watches the queue for stalls until the context is done and returns the
+// context error. Time in which the queue cannot be fetched counts as time
+// without progress.
+func (d *StallDetector) Run(ctx context.Context) error {
+	defer close(d.reports)
+	return pollEvery(ctx, d.interval, func() {
+		groups, err := d.client.FileGroups()
+		if err != nil {
+			return
+		}
+		for _, report := range d.check(groups) {
+			select {
+			case d.reports <- report:
+			default:
+			}
+		}
+	})
+}
+
+// stallReason returns the kind of progress expected from a group in the
+// given status, if any.
+func stallReason(status GroupStatus) (StallReason, bool) {
+	switch {
+	case status == GroupStatusDownloading:
+		return StallDownload, true
+	case status.IsPostProcessing() && status != GroupStatusPPQueued && !status.IsTerminal():
+		return StallPostProcessing, true
+	}
+	return "", false
+}
+
+func (d *StallDetector) check(groups []FileGroup) []StallReport {
+	now := d.now()
+	states := make(map[int]stallState, len(groups))
+	var reports []StallReport
+	for _, group := range groups {
+		current := stallState{
+			status:       group.Status,
+			remaining:    group.RemainingSize(),
+			postProgress: group.PostStageProgress,
+			since:        now,
+			last:         now,
+		}
+		previous, known := d.states[group.NZBID]
+		reason, expected := stallReason(group.Status)
+		if !known || previous.progressed(current) || (!expected && previous.reported == 0) {
+			states[group.NZBID] = current
+			continue
+		}
+
+		// The recovery steps pause or queue the group, which must not end
+		// the escalation.
+		state := previous
+		state.status = group.Status
+		state.last = now
+		if !expected {
+			state.waited += now.Sub(previous.last)
+			states[group.NZBID] = state
+			continue
+		}
+
+		steps := d.steps[reason]
+		limit := len(steps)
+		if limit == 0 {
+			limit = 1
+		}
+		stalled := now.Sub(state.since) - state.waited
+		if state.reported < limit && stalled >= d.window*time.Duration(state.reported+1) {
+			report := StallReport{
+				Time:   now,
+				NZBID:  group.NZBID,
+				Name:   group.NZBName,
+				Status: group.Status,
+				Reason: reason,
+				Since:  state.since,
+			}
+			if state.reported < len(steps) {
+				report.Step = steps[state.reported]
+				report.Err = d.recover(report.Step, reason, group.NZBID)
+			}
+			state.reported++
+			reports = append(reports, report)
+		}
+		states[group.NZBID] = state
+	}
+	d.states = states
+	return reports
+}
+
+// progressed reports whether the group made progress between the states: its
+// remaining size went down or it entered or advanced in post-processing.
+// Changes between waiting and downloading are no progress.
+func (s stallState) progressed(current stallState) bool {
+	if current.remaining < s.remaining {
+		return true
+	}
+	return current.status.IsPostProcessing() &&
+		(current.status != s.status || current.postProgress != s.postProgress)
+}
+
+func (d *StallDetector) recover(step StallStep, reason StallReason, nzbID int) error {
+	switch step {
+	case StallStepPauseResume:
+		if err := d.client.edit(EditGroupPause, "", nzbID); err != nil {
+			return err
+		}
+		return d.client.edit(EditGroupResume, "", nzbID)
+	case StallStepMoveDown:
+		return d.client.edit(EditGroupMoveBottom, "", nzbID)
+	case StallStepToHistory:
+		if reason == StallPostProcessing {
+			return d.client.edit(EditPostDelete, "", nzbID)
+		}
+		return d.client.edit(EditGroupParkDelete, "", nzbID)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package nzbget_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// stallGroups returns a queue with a downloading group with the given
+// remaining size, an unpacking group with the given stage progress and a
+// paused group.
+func stallGroups(remaining, progress int) string {
+	return fmt.Sprintf(`{"version": "1.1", "result": [
+  {"NZBID": 1, "NZBName": "Linux.ISO", "Status": "DOWNLOADING", "RemainingSizeLo": %d},
+  {"NZBID": 2, "NZBName": "Photos", "Status": "UNPACKING", "PostStageProgress": %d},
+  {"NZBID": 3, "NZBName": "Music", "Status": "PAUSED", "RemainingSizeLo": 1024}
+]}`, remaining, progress)
+}
+
+// stallDownload returns a queue with a single group in the given status and
+// with the given remaining size.
+func stallDownload(status nzbget.GroupStatus, remaining int) string {
+	return fmt.Sprintf(`{"version": "1.1", "result": [
+  {"NZBID": 1, "NZBName": "Linux.ISO", "Status": %q, "RemainingSizeLo": %d}
+]}`, status, remaining)
+}
+
+var _ = Describe("StallDetector", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	noon := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive())
+		gock.Off()
+	})
+
+	It("should report stalled groups and escalate the recovery", func() {
+		mockGroups(stallGroups(4096, 100))
+		mockGroups(stallGroups(4096, 100))
+		mockGroups(stallGroups(4096, 100))
+		mockEdit(nzbget.EditGroupPause, "", []int{1}, true)
+		mockEdit(nzbget.EditGroupResume, "", []int{1}, true)
+		mockEdit(nzbget.EditPostDelete, "", []int{2}, false)
+		mockGroups(stallGroups(4096, 200))
+		mockGroups(stallGroups(4096, 200))
+		mockEdit(nzbget.EditGroupMoveBottom, "", []int{1}, true)
+		mockGroups(stallGroups(4096, 300))
+		mockEdit(nzbget.EditGroupParkDelete, "", []int{1}, true)
+
+		times := []time.Time{
+			noon,
+			noon.Add(5 * time.Minute),
+			noon.Add(10 * time.Minute),
+			noon.Add(15 * time.Minute),
+			noon.Add(20 * time.Minute),
+			noon.Add(30 * time.Minute),
+		}
+		clock := func() time.Time {
+			now := times[0]
+			if len(times) > 1 {
+				times = times[1:]
+			}
+			return now
+		}
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		detector := nzbget.NewStallDetector(client,
+			nzbget.WithStallInterval(time.Millisecond),
+			nzbget.WithStallWindow(10*time.Minute),
+			nzbget.WithStallRecovery(nzbget.StallDownload,
+				nzbget.StallStepPauseResume, nzbget.StallStepMoveDown, nzbget.StallStepToHistory),
+			nzbget.WithStallRecovery(nzbget.StallPostProcessing,
+				nzbget.StallStepMoveDown, nzbget.StallStepToHistory),
+			nzbget.WithStallClock(clock))
+		done = start(ctx, detector)
+
+		reports := receive(detector.Reports(), 4).([]nzbget.StallReport)
+		Expect(reports[0]).To(MatchFields(IgnoreExtras, Fields{
+			"Time":   Equal(noon.Add(10 * time.Minute)),
+			"NZBID":  Equal(1),
+			"Reason": Equal(nzbget.StallDownload),
+			"Since":  Equal(noon),
+			"Step":   Equal(nzbget.StallStepPauseResume),
+			"Err":    BeNil(),
+		}))
+		Expect(reports[1]).To(MatchFields(IgnoreExtras, Fields{
+			"NZBID":  Equal(2),
+			"Status": Equal(nzbget.GroupStatusUnpacking),
+			"Reason": Equal(nzbget.StallPostProcessing),
+			"Step":   Equal(nzbget.StallStepToHistory),
+			"Err":    Equal(nzbget.ErrEditRejected),
+		}))
+		Expect(reports[2]).To(MatchFields(IgnoreExtras, Fields{
+			"Time":  Equal(noon.Add(20 * time.Minute)),
+			"NZBID": Equal(1),
+			"Since": Equal(noon),
+			"Step":  Equal(nzbget.StallStepMoveDown),
+		}))
+		Expect(reports[3]).To(MatchFields(IgnoreExtras, Fields{
+			"Time":  Equal(noon.Add(30 * time.Minute)),
+			"NZBID": Equal(1),
+			"Step":  Equal(nzbget.StallStepToHistory),
+		}))
+		Eventually(gock.IsDone).Should(BeTrue())
+	})
+
+	It("should only report a stall once without recovery steps", func() {
+		mockGroups(stallGroups(4096, 100))
+		mockGroups(stallGroups(4096, 100))
+		mockGroups(stallGroups(4096, 100))
+		mockGroups(stallGroups(2048, 100))
+
+		times := []time.Time{
+			noon,
+			noon.Add(10 * time.Minute),
+			noon.Add(20 * time.Minute),
+			noon.Add(30 * time.Minute),
+		}
+		clock := func() time.Time {
+			now := times[0]
+			if len(times) > 1 {
+				times = times[1:]
+			}
+			return now
+		}
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		detector := nzbget.NewStallDetector(client,
+			nzbget.WithStallInterval(time.Millisecond),
+			nzbget.WithStallClock(clock))
+		done = start(ctx, detector)
+
+		reports := receive(detector.Reports(), 2).([]nzbget.StallReport)
+		Expect(reports[0].NZBID).To(Equal(1))
+		Expect(reports[0].Step).To(BeEmpty())
+		Expect(reports[1].NZBID).To(Equal(2))
+		Eventually(gock.IsDone).Should(BeTrue())
+		Consistently(detector.Reports()).ShouldNot(Receive())
+	})
+
+	It("should keep escalating when a recovery step queues the group", func() {
+		mockGroups(stallDownload(nzbget.GroupStatusDownloading, 4096))
+		mockGroups(stallDownload(nzbget.GroupStatusDownloading, 4096))
+		mockEdit(nzbget.EditGroupPause, "", []int{1}, true)
+		mockEdit(nzbget.EditGroupResume, "", []int{1}, true)
+		mockGroups(stallDownload(nzbget.GroupStatusQueued, 4096))
+		mockGroups(stallDownload(nzbget.GroupStatusDownloading, 4096))
+		mockGroups(stallDownload(nzbget.GroupStatusDownloading, 4096))
+		mockEdit(nzbget.EditGroupMoveBottom, "", []int{1}, true)
+		mockGroups(stallDownload(nzbget.GroupStatusQueued, 4096))
+		mockGroups(stallDownload(nzbget.GroupStatusDownloading, 4096))
+		mockGroups(stallDownload(nzbget.GroupStatusDownloading, 4096))
+		mockEdit(nzbget.EditGroupParkDelete, "", []int{1}, true)
+
+		times := []time.Time{
+			noon,
+			noon.Add(10 * time.Minute),
+			noon.Add(15 * time.Minute),
+			noon.Add(20 * time.Minute),
+			noon.Add(25 * time.Minute),
+			noon.Add(30 * time.Minute),
+			noon.Add(35 * time.Minute),
+			noon.Add(40 * time.Minute),
+		}
+		clock := func() time.Time {
+			now := times[0]
+			if len(times) > 1 {
+				times = times[1:]
+			}
+			return now
+		}
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		detector := nzbget.NewStallDetector(client,
+			nzbget.WithStallInterval(time.Millisecond),
+			nzbget.WithStallRecovery(nzbget.StallDownload,
+				nzbget.StallStepPauseResume, nzbget.StallStepMoveDown, nzbget.StallStepToHistory),
+			nzbget.WithStallClock(clock))
+		done = start(ctx, detector)
+
+		reports := receive(detector.Reports(), 3).([]nzbget.StallReport)
+		Expect(reports[0].Step).To(Equal(nzbget.StallStepPauseResume))
+		Expect(reports[1]).To(MatchFields(IgnoreExtras, Fields{
+			"Time":  Equal(noon.Add(25 * time.Minute)),
+			"Since": Equal(noon),
+			"Step":  Equal(nzbget.StallStepMoveDown),
+		}))
+		Expect(reports[2]).To(MatchFields(IgnoreExtras, Fields{
+			"Time": Equal(noon.Add(40 * time.Minute)),
+			"Step": Equal(nzbget.StallStepToHistory),
+			"Err":  BeNil(),
+		}))
+		Eventually(gock.IsDone).Should(BeTrue())
+	})
+})
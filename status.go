@@ -0,0 +1,261 @@
+package nzbget
+
+// The status fields of FileGroup and HistoricalEntry use the named string
+// types below. Values unknown to this package, e.g. sent by newer servers,
+// are kept as received.
+
+// GroupStatus is the status of a group in the download queue
+type GroupStatus string
+
+// Statuses of groups in the download queue
+const (
+	GroupStatusQueued            GroupStatus = "QUEUED"
+	GroupStatusPaused            GroupStatus = "PAUSED"
+	GroupStatusDownloading       GroupStatus = "DOWNLOADING"
+	GroupStatusFetching          GroupStatus = "FETCHING"
+	GroupStatusPPQueued          GroupStatus = "PP_QUEUED"
+	GroupStatusLoadingPars       GroupStatus = "LOADING_PARS"
+	GroupStatusVerifyingSources  GroupStatus = "VERIFYING_SOURCES"
+	GroupStatusRepairing         GroupStatus = "REPAIRING"
+	GroupStatusVerifyingRepaired GroupStatus = "VERIFYING_REPAIRED"
+	GroupStatusRenaming          GroupStatus = "RENAMING"
+	GroupStatusUnpacking         GroupStatus = "UNPACKING"
+	GroupStatusMoving            GroupStatus = "MOVING"
+	GroupStatusExecutingScript   GroupStatus = "EXECUTING_SCRIPT"
+	GroupStatusPPFinished        GroupStatus = "PP_FINISHED"
+)
+
+// IsKnown reports whether the status is one of the statuses defined by this
+// package.
+func (s GroupStatus) IsKnown() bool {
+	switch s {
+	case GroupStatusQueued, GroupStatusPaused, GroupStatusDownloading, GroupStatusFetching:
+		return true
+	}
+	return s.IsPostProcessing()
+}
+
+// IsDownloading reports whether the group or URL is being downloaded.
+func (s GroupStatus) IsDownloading() bool {
+	return s == GroupStatusDownloading || s == GroupStatusFetching
+}
+
+// IsPaused reports whether the group is paused.
+func (s GroupStatus) IsPaused() bool {
+	return s == GroupStatusPaused
+}
+
+// IsPostProcessing reports whether the group is completely downloaded and
+// queued for or in one of the stages of post-processing.
+func (s GroupStatus) IsPostProcessing() bool {
+	switch s {
+	case GroupStatusPPQueued,
+		GroupStatusLoadingPars,
+		GroupStatusVerifyingSources,
+		GroupStatusRepairing,
+		GroupStatusVerifyingRepaired,
+		GroupStatusRenaming,
+		GroupStatusUnpacking,
+		GroupStatusMoving,
+		GroupStatusExecutingScript,
+		GroupStatusPPFinished:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether processing of the group is finished and it is
+// about to be moved to history.
+func (s GroupStatus) IsTerminal() bool {
+	return s == GroupStatusPPFinished
+}
+
+// ParStatus is the result of par-check/repair
+type ParStatus string
+
+// Results of par-check/repair
+const (
+	ParStatusNone           ParStatus = "NONE"
+	ParStatusFailure        ParStatus = "FAILURE"
+	ParStatusRepairPossible ParStatus = "REPAIR_POSSIBLE"
+	ParStatusSuccess        ParStatus = "SUCCESS"
+	ParStatusManual         ParStatus = "MANUAL"
+)
+
+// IsSuccess reports whether the par-check or repair was successful.
+func (s ParStatus) IsSuccess() bool {
+	return s == ParStatusSuccess
+}
+
+// IsFailure reports whether the par-check has failed.
+func (s ParStatus) IsFailure() bool {
+	return s == ParStatusFailure
+}
+
+// IsDamaged reports whether the download is damaged but was not repaired.
+func (s ParStatus) IsDamaged() bool {
+	return s == ParStatusRepairPossible || s == ParStatusManual
+}
+
+// ExParStatus indicates if the download was repaired using duplicate par-scan
+// mode
+type ExParStatus string
+
+// Roles in duplicate par-scan mode
+const (
+	ExParStatusNone      ExParStatus = "NONE"
+	ExParStatusRecipient ExParStatus = "RECIPIENT"
+	ExParStatusDonor     ExParStatus = "DONOR"
+)
+
+// UnpackStatus is the result of unpack
+type UnpackStatus string
+
+// Results of unpack
+const (
+	UnpackStatusNone     UnpackStatus = "NONE"
+	UnpackStatusFailure  UnpackStatus = "FAILURE"
+	UnpackStatusSpace    UnpackStatus = "SPACE"
+	UnpackStatusPassword UnpackStatus = "PASSWORD"
+	UnpackStatusSuccess  UnpackStatus = "SUCCESS"
+)
+
+// IsSuccess reports whether the unpack was successful.
+func (s UnpackStatus) IsSuccess() bool {
+	return s == UnpackStatusSuccess
+}
+
+// IsFailure reports whether the unpack has failed for any reason.
+func (s UnpackStatus) IsFailure() bool {
+	return s == UnpackStatusFailure || s == UnpackStatusSpace || s == UnpackStatusPassword
+}
+
+// MoveStatus is the result of moving files from the intermediate directory
+// into the final directory
+type MoveStatus string
+
+// Results of moving files
+const (
+	MoveStatusNone    MoveStatus = "NONE"
+	MoveStatusSuccess MoveStatus = "SUCCESS"
+	MoveStatusFailure MoveStatus = "FAILURE"
+)
+
+// IsSuccess reports whether the files were moved successfully.
+func (s MoveStatus) IsSuccess() bool {
+	return s == MoveStatusSuccess
+}
+
+// IsFailure reports whether the moving has failed.
+func (s MoveStatus) IsFailure() bool {
+	return s == MoveStatusFailure
+}
+
+// ScriptResult is the result of post-processing scripts
+type ScriptResult string
+
+// Results of post-processing scripts
+const (
+	ScriptResultNone    ScriptResult = "NONE"
+	ScriptResultFailure ScriptResult = "FAILURE"
+	ScriptResultSuccess ScriptResult = "SUCCESS"
+)
+
+// IsSuccess reports whether the scripts were successful.
+func (s ScriptResult) IsSuccess() bool {
+	return s == ScriptResultSuccess
+}
+
+// IsFailure reports whether a script has failed.
+func (s ScriptResult) IsFailure() bool {
+	return s == ScriptResultFailure
+}
+
+// DeleteStatus indicates if and why a download was deleted
+type DeleteStatus string
+
+// Reasons of deletion
+const (
+	DeleteStatusNone   DeleteStatus = "NONE"
+	DeleteStatusManual DeleteStatus = "MANUAL"
+	DeleteStatusHealth DeleteStatus = "HEALTH"
+	DeleteStatusDupe   DeleteStatus = "DUPE"
+	DeleteStatusBad    DeleteStatus = "BAD"
+	DeleteStatusGood   DeleteStatus = "GOOD"
+	DeleteStatusScan   DeleteStatus = "SCAN"
+	DeleteStatusCopy   DeleteStatus = "COPY"
+)
+
+// IsDeleted reports whether the download was deleted.
+func (s DeleteStatus) IsDeleted() bool {
+	return s != "" && s != DeleteStatusNone
+}
+
+// IsFailure reports whether the deletion means the download has failed, as
+// opposed to having been deleted by the user or as a duplicate.
+func (s DeleteStatus) IsFailure() bool {
+	return s == DeleteStatusHealth || s == DeleteStatusBad || s == DeleteStatusScan
+}
+
+// MarkStatus indicates if a download was marked by user
+type MarkStatus string
+
+// Marks set by user
+const (
+	MarkStatusNone    MarkStatus = "NONE"
+	MarkStatusGood    MarkStatus = "GOOD"
+	MarkStatusBad     MarkStatus = "BAD"
+	MarkStatusSuccess MarkStatus = "SUCCESS"
+)
+
+// IsFailure reports whether the download was marked as bad.
+func (s MarkStatus) IsFailure() bool {
+	return s == MarkStatusBad
+}
+
+// URLStatus is the result of a URL download
+type URLStatus string
+
+// Results of URL downloads
+const (
+	URLStatusNone        URLStatus = "NONE"
+	URLStatusSuccess     URLStatus = "SUCCESS"
+	URLStatusFailure     URLStatus = "FAILURE"
+	URLStatusScanSkipped URLStatus = "SCAN_SKIPPED"
+	URLStatusScanFailure URLStatus = "SCAN_FAILURE"
+)
+
+// IsSuccess reports whether the nzb-file was fetched from the URL.
+func (s URLStatus) IsSuccess() bool {
+	return s == URLStatusSuccess
+}
+
+// IsFailure reports whether fetching or scanning of the URL has failed.
+func (s URLStatus) IsFailure() bool {
+	return s == URLStatusFailure || s == URLStatusScanFailure
+}
+
+// DupStatus is the status of a hidden duplicate history entry
+type DupStatus string
+
+// Statuses of hidden duplicate history entries
+const (
+	DupStatusUnknown DupStatus = "UNKNOWN"
+	DupStatusSuccess DupStatus = "SUCCESS"
+	DupStatusFailure DupStatus = "FAILURE"
+	DupStatusDeleted DupStatus = "DELETED"
+	DupStatusDupe    DupStatus = "DUPE"
+	DupStatusBad     DupStatus = "BAD"
+	DupStatusGood    DupStatus = "GOOD"
+)
+
+// IsSuccess reports whether the duplicate was downloaded successfully or
+// marked as good.
+func (s DupStatus) IsSuccess() bool {
+	return s == DupStatusSuccess || s == DupStatusGood
+}
+
+// IsFailure reports whether the duplicate has failed or was marked as bad.
+func (s DupStatus) IsFailure() bool {
+	return s == DupStatusFailure || s == DupStatusBad
+}
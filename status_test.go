@@ -0,0 +1,91 @@
+package nzbget_test
+
+import (
+	"encoding/json"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Statuses", func() {
+
+	DescribeTable("GroupStatus",
+		func(status nzbget.GroupStatus, downloading, postProcessing, terminal, known bool) {
+			Expect(status.IsDownloading()).To(Equal(downloading))
+			Expect(status.IsPostProcessing()).To(Equal(postProcessing))
+			Expect(status.IsTerminal()).To(Equal(terminal))
+			Expect(status.IsKnown()).To(Equal(known))
+		},
+		Entry("queued", nzbget.GroupStatusQueued, false, false, false, true),
+		Entry("paused", nzbget.GroupStatusPaused, false, false, false, true),
+		Entry("downloading", nzbget.GroupStatusDownloading, true, false, false, true),
+		Entry("fetching", nzbget.GroupStatusFetching, true, false, false, true),
+		Entry("queued for post-processing", nzbget.GroupStatusPPQueued, false, true, false, true),
+		Entry("repairing", nzbget.GroupStatusRepairing, false, true, false, true),
+		Entry("unpacking", nzbget.GroupStatusUnpacking, false, true, false, true),
+		Entry("executing script", nzbget.GroupStatusExecutingScript, false, true, false, true),
+		Entry("finished", nzbget.GroupStatusPPFinished, false, true, true, true),
+		Entry("unknown", nzbget.GroupStatus("QS_QUEUED"), false, false, false, false),
+	)
+
+	DescribeTable("IsFailure",
+		func(failure bool, isFailure func() bool) {
+			Expect(isFailure()).To(Equal(failure))
+		},
+		Entry("par failure", true, nzbget.ParStatusFailure.IsFailure),
+		Entry("par repair possible", false, nzbget.ParStatusRepairPossible.IsFailure),
+		Entry("unpack space", true, nzbget.UnpackStatusSpace.IsFailure),
+		Entry("unpack password", true, nzbget.UnpackStatusPassword.IsFailure),
+		Entry("unpack none", false, nzbget.UnpackStatusNone.IsFailure),
+		Entry("move failure", true, nzbget.MoveStatusFailure.IsFailure),
+		Entry("script failure", true, nzbget.ScriptResultFailure.IsFailure),
+		Entry("delete health", true, nzbget.DeleteStatusHealth.IsFailure),
+		Entry("delete dupe", false, nzbget.DeleteStatusDupe.IsFailure),
+		Entry("mark bad", true, nzbget.MarkStatusBad.IsFailure),
+		Entry("url scan failure", true, nzbget.URLStatusScanFailure.IsFailure),
+		Entry("url scan skipped", false, nzbget.URLStatusScanSkipped.IsFailure),
+		Entry("dup bad", true, nzbget.DupStatusBad.IsFailure),
+	)
+
+	It("should report deleted downloads", func() {
+		Expect(nzbget.DeleteStatusNone.IsDeleted()).To(BeFalse())
+		Expect(nzbget.DeleteStatus("").IsDeleted()).To(BeFalse())
+		Expect(nzbget.DeleteStatusCopy.IsDeleted()).To(BeTrue())
+	})
+
+	It("should keep unknown values when decoding", func() {
+		var group nzbget.FileGroup
+		err := json.Unmarshal([]byte(`{"Status": "QS_EXECUTING", "ParStatus": "SKIPPED"}`), &group)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(group.Status).To(Equal(nzbget.GroupStatus("QS_EXECUTING")))
+		Expect(group.ParStatus).To(Equal(nzbget.ParStatus("SKIPPED")))
+	})
+
+	Context("file groups", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/listgroups").
+				MatchParams(map[string]string{}).
+				Reply(200).
+				JSON(listGroups)
+		})
+
+		It("should decode the typed statuses", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			groups, err := client.FileGroups()
+			Expect(err).ToNot(HaveOccurred())
+			for _, group := range groups {
+				Expect(group.Status.IsKnown()).To(BeTrue())
+				Expect(group.DeleteStatus).To(Equal(nzbget.DeleteStatusNone))
+			}
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package nzbget
+
+// SysInfo is the system information reported by the server. It describes the
+// host NZBGet runs on and the external tools it uses for unpacking.
+type SysInfo struct {
+	// OS is the operating system of the computer running NZBGet.
+	OS struct {
+		// Name is the name of the operating system, e.g. “Linux”.
+		Name string `json:"Name"`
+
+		// Version is the version of the operating system.
+		Version string `json:"Version"`
+	} `json:"OS"`
+
+	// CPU is the processor of the computer running NZBGet.
+	CPU struct {
+		// Model is the model name of the processor.
+		Model string `json:"Model"`
+
+		// Arch is the architecture of the processor, e.g. “x86_64”.
+		Arch string `json:"Arch"`
+	} `json:"CPU"`
+
+	// Network is the network information of the computer running NZBGet.
+	Network struct {
+		// PublicIP is the public IP address as seen from the internet.
+		PublicIP string `json:"PublicIP"`
+
+		// PrivateIP is the IP address of the local network interface.
+		PrivateIP string `json:"PrivateIP"`
+	} `json:"Network"`
+
+	// Tools is the list of external programs used by NZBGet, such as unrar
+	// and 7z.
+	Tools []SysTool `json:"Tools"`
+
+	// Libraries is the list of libraries NZBGet was built with.
+	Libraries []SysLibrary `json:"Libraries"`
+}
+
+// SysTool is an external program used by NZBGet.
+type SysTool struct {
+	// Name is the name of the tool, e.g. “UnRAR” or “7-Zip”.
+	Name string `json:"Name"`
+
+	// Version is the version of the tool or empty string if the tool could
+	// not be found.
+	Version string `json:"Version"`
+
+	// Path is the path to the executable of the tool.
+	Path string `json:"Path"`
+}
+
+// SysLibrary is a library NZBGet was built with.
+type SysLibrary struct {
+	// Name is the name of the library, e.g. “OpenSSL”.
+	Name string `json:"Name"`
+
+	// Version is the version of the library.
+	Version string `json:"Version"`
+}
+
+// Tool returns the tool with the given name. The second return value is false
+// if the server does not report such a tool.
+func (s SysInfo) Tool(name string) (SysTool, bool) {
+	for _, tool := range s.Tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return SysTool{}, false
+}
+
+// SysInfo returns the system information of the server
+func (n NZBGet) SysInfo() (*SysInfo, error) {
+	var sysInfo SysInfo
+	err := n.get("sysinfo", &sysInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &sysInfo, nil
+}
@@ -0,0 +1,77 @@
+package nzbget_test
+
+import (
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const sysInfo = `{
+  "version": "1.1",
+  "result": {
+    "OS": {
+      "Name": "Linux",
+      "Version": "6.1.0"
+    },
+    "CPU": {
+      "Model": "Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz",
+      "Arch": "x86_64"
+    },
+    "Network": {
+      "PublicIP": "203.0.113.7",
+      "PrivateIP": "192.168.1.10"
+    },
+    "Tools": [
+      {
+        "Name": "UnRAR",
+        "Version": "6.24",
+        "Path": "\/usr\/bin\/unrar"
+      },
+      {
+        "Name": "7-Zip",
+        "Version": "17.05",
+        "Path": "\/usr\/bin\/7z"
+      }
+    ],
+    "Libraries": [
+      {
+        "Name": "OpenSSL",
+        "Version": "3.0.11"
+      }
+    ]
+  }
+}`
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#SysInfo", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/sysinfo").
+					MatchParams(map[string]string{}).
+					Reply(200).
+					JSON(sysInfo)
+			})
+
+			It("should return the system information", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				info, err := client.SysInfo()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info.OS.Name).To(Equal("Linux"))
+				Expect(info.CPU.Arch).To(Equal("x86_64"))
+				unrar, ok := info.Tool("UnRAR")
+				Expect(ok).To(BeTrue())
+				Expect(unrar.Version).To(Equal("6.24"))
+				_, ok = info.Tool("par2")
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package nzbget
+
+import "time"
+
+// unixTime converts a C/Unix timestamp into a time in UTC. Zero means the
+// value is not set and results in the zero time.
+func unixTime(sec int) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0).UTC()
+}
+
+// seconds converts a number of seconds into a duration.
+func seconds(sec int) time.Duration {
+	return time.Duration(sec) * time.Second
+}
+
+// MinPostTimeUTC returns the time the oldest file in the group was posted, or
+// the zero time if unknown.
+func (f FileGroup) MinPostTimeUTC() time.Time {
+	return unixTime(f.MinPostTime)
+}
+
+// MaxPostTimeUTC returns the time the newest file in the group was posted, or
+// the zero time if unknown.
+func (f FileGroup) MaxPostTimeUTC() time.Time {
+	return unixTime(f.MaxPostTime)
+}
+
+// DownloadTime returns the download time of the group.
+func (f FileGroup) DownloadTime() time.Duration {
+	return seconds(f.DownloadTimeSec)
+}
+
+// ParTime returns the par-check time of the group, including verification
+// and repair.
+func (f FileGroup) ParTime() time.Duration {
+	return seconds(f.ParTimeSec)
+}
+
+// RepairTime returns the par-repair time of the group.
+func (f FileGroup) RepairTime() time.Duration {
+	return seconds(f.RepairTimeSec)
+}
+
+// UnpackTime returns the unpack time of the group.
+func (f FileGroup) UnpackTime() time.Duration {
+	return seconds(f.UnpackTimeSec)
+}
+
+// PostStageTime returns how long the current post-processing stage is being
+// processed.
+func (f FileGroup) PostStageTime() time.Duration {
+	return seconds(f.PostStageTimeSec)
+}
+
+// PostTotalTime returns how long the group is being post-processed.
+func (f FileGroup) PostTotalTime() time.Duration {
+	return seconds(f.PostTotalTimeSec)
+}
+
+// DownloadTime returns the server download time.
+func (s Status) DownloadTime() time.Duration {
+	return seconds(s.DownloadTimeSec)
+}
+
+// UpTime returns the server uptime.
+func (s Status) UpTime() time.Duration {
+	return seconds(s.UpTimeSec)
+}
+
+// ServerTimeUTC returns the current time on the computer running NZBGet.
+func (s Status) ServerTimeUTC() time.Time {
+	return unixTime(s.ServerTime)
+}
+
+// ResumeTimeUTC returns the time the download is resumed at, or the zero time
+// if no resume is scheduled.
+func (s Status) ResumeTimeUTC() time.Time {
+	return unixTime(s.ResumeTime)
+}
+
+// DataTimeUTC returns the time the volume data was last updated.
+func (v ServerVolume) DataTimeUTC() time.Time {
+	return unixTime(v.DataTime)
+}
+
+// CustomTimeUTC returns the time of the last reset of the custom counter, or
+// the zero time if it was never reset.
+func (v ServerVolume) CustomTimeUTC() time.Time {
+	return unixTime(v.CustomTime)
+}
+
+// FirstDayUTC returns the midnight of the calendar day the first slot of
+// BytesPerDays corresponds to, or the zero time if unknown. FirstDay counts
+// days since January 1, 1970 in the local time of the server, so the result
+// is the calendar date expressed in UTC rather than the exact instant the day
+// started on the server.
+func (v ServerVolume) FirstDayUTC() time.Time {
+	return unixTime(v.FirstDay * 24 * 60 * 60)
+}
+
+// HistoryTimeUTC returns the time the entry was added to history.
+func (h HistoricalEntry) HistoryTimeUTC() time.Time {
+	return unixTime(h.HistoryTime)
+}
+
+// MinPostTimeUTC returns the time the oldest file of the entry was posted, or
+// the zero time if unknown.
+func (h HistoricalEntry) MinPostTimeUTC() time.Time {
+	return unixTime(h.MinPostTime)
+}
+
+// MaxPostTimeUTC returns the time the newest file of the entry was posted, or
+// the zero time if unknown.
+func (h HistoricalEntry) MaxPostTimeUTC() time.Time {
+	return unixTime(h.MaxPostTime)
+}
+
+// DownloadTime returns the download time of the entry.
+func (h HistoricalEntry) DownloadTime() time.Duration {
+	return seconds(h.DownloadTimeSec)
+}
+
+// PostTotalTime returns the total post-processing time of the entry.
+func (h HistoricalEntry) PostTotalTime() time.Duration {
+	return seconds(h.PostTotalTimeSec)
+}
+
+// ParTime returns the par-check time of the entry, including verification
+// and repair.
+func (h HistoricalEntry) ParTime() time.Duration {
+	return seconds(h.ParTimeSec)
+}
+
+// RepairTime returns the par-repair time of the entry.
+func (h HistoricalEntry) RepairTime() time.Duration {
+	return seconds(h.RepairTimeSec)
+}
+
+// UnpackTime returns the unpack time of the entry.
+func (h HistoricalEntry) UnpackTime() time.Duration {
+	return seconds(h.UnpackTimeSec)
+}
+
+// TimeUTC returns the time the feed item was published, or the zero time if
+// unknown.
+func (f FeedItem) TimeUTC() time.Time {
+	return unixTime(f.Time)
+}
+
+// TimeUTC returns the time the message was logged.
+func (l LogMessage) TimeUTC() time.Time {
+	return unixTime(l.Time)
+}
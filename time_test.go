@@ -0,0 +1,72 @@
+package nzbget_test
+
+import (
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Time accessors", func() {
+
+	Context("status", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/status").
+				MatchParams(map[string]string{}).
+				Reply(200).
+				JSON(status)
+		})
+
+		It("should convert timestamps and durations", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			status, err := client.Status()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.ServerTimeUTC()).To(Equal(time.Date(2020, 5, 17, 3, 52, 11, 0, time.UTC)))
+			Expect(status.ResumeTimeUTC().IsZero()).To(BeTrue())
+			Expect(status.UpTime()).To(Equal(1036715 * time.Second))
+			Expect(status.DownloadTime()).To(Equal(3803 * time.Second))
+		})
+	})
+
+	Context("history", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		BeforeEach(func() {
+			gock.New(nzbgetURL).
+				Get("/jsonrpc/history").
+				MatchParams(map[string]string{}).
+				Reply(200).
+				JSON(history)
+		})
+
+		It("should convert timestamps and durations", func() {
+			client, err := nzbget.New(nzbgetURL, "user", "password")
+			Expect(err).ToNot(HaveOccurred())
+			history, err := client.History()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history[0].HistoryTimeUTC().Location()).To(Equal(time.UTC))
+			Expect(history[0].HistoryTimeUTC().Unix()).To(Equal(int64(1589707990)))
+			Expect(history[0].MinPostTimeUTC()).To(Equal(time.Date(2016, 7, 23, 8, 53, 8, 0, time.UTC)))
+			Expect(history[0].DownloadTime()).To(Equal(3 * time.Second))
+			Expect(history[0].ParTime()).To(BeZero())
+		})
+	})
+
+	Context("server volumes", func() {
+		It("should return the first day as a calendar date", func() {
+			volume := nzbget.ServerVolume{FirstDay: 18399}
+			Expect(volume.FirstDayUTC()).To(Equal(time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)))
+			Expect(nzbget.ServerVolume{}.FirstDayUTC().IsZero()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,201 @@
+package nzbget
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// UpdateBranch is a release branch NZBGet can be updated from
+type UpdateBranch string
+
+// Release branches available for updates
+const (
+	UpdateBranchStable  UpdateBranch = "stable"
+	UpdateBranchTesting UpdateBranch = "testing"
+	UpdateBranchDevel   UpdateBranch = "devel"
+)
+
+// ErrUpdateNotStarted is returned when the server refuses to start an update.
+var ErrUpdateNotStarted = errors.New("nzbget: update could not be started")
+
+// UpdateInfo is the information about available releases as returned by the
+// update-info script of the installation.
+type UpdateInfo struct {
+	// StableVersion is the version of the latest stable release.
+	StableVersion string `json:"stable-version"`
+
+	// StableDate is the release date of the latest stable release.
+	StableDate string `json:"stable-date"`
+
+	// StableReleaseNotes is the URL of the release notes of the latest stable
+	// release.
+	StableReleaseNotes string `json:"stable-release-notes"`
+
+	// TestingVersion is the version of the latest testing release.
+	TestingVersion string `json:"testing-version"`
+
+	// TestingDate is the release date of the latest testing release.
+	TestingDate string `json:"testing-date"`
+
+	// TestingReleaseNotes is the URL of the release notes of the latest
+	// testing release.
+	TestingReleaseNotes string `json:"testing-release-notes"`
+
+	// DevelVersion is the version of the latest development build.
+	DevelVersion string `json:"devel-version"`
+
+	// DevelDate is the build date of the latest development build.
+	DevelDate string `json:"devel-date"`
+
+	// DevelReleaseNotes is the URL of the release notes of the latest
+	// development build.
+	DevelReleaseNotes string `json:"devel-release-notes"`
+}
+
+// Version returns the latest version available on the given branch or empty
+// string if the branch has no release.
+func (u UpdateInfo) Version(branch UpdateBranch) string {
+	switch branch {
+	case UpdateBranchStable:
+		return u.StableVersion
+	case UpdateBranchTesting:
+		return u.TestingVersion
+	case UpdateBranchDevel:
+		return u.DevelVersion
+	}
+	return ""
+}
+
+// CheckUpdates returns the releases available for installation
+func (n NZBGet) CheckUpdates() (*UpdateInfo, error) {
+	var info UpdateInfo
+	err := n.get("checkupdates", &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// StartUpdate starts the update from the given branch. It returns false if
+// the update could not be started.
+func (n NZBGet) StartUpdate(branch UpdateBranch) (bool, error) {
+	var started bool
+	err := n.call("startupdate", &started, string(branch))
+	if err != nil {
+		return false, err
+	}
+	return started, nil
+}
+
+// UpdateLog returns the messages of the update process starting with the
+// message idFrom. If idFrom is 0 the last count messages are returned.
+func (n NZBGet) UpdateLog(idFrom, count int) ([]LogMessage, error) {
+	var messages []LogMessage
+	err := n.call("logupdate", &messages, idFrom, count)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// UpdateOption configures UpdateAndWait
+type UpdateOption func(*updateOptions)
+
+type updateOptions struct {
+	interval time.Duration
+	onLog    func(LogMessage)
+}
+
+// WithUpdateInterval sets how often the update log and the server status are
+// polled. The default is one second.
+func WithUpdateInterval(interval time.Duration) UpdateOption {
+	return func(o *updateOptions) {
+		o.interval = interval
+	}
+}
+
+// WithUpdateLog registers a function receiving each message of the update
+// log in order.
+func WithUpdateLog(onLog func(LogMessage)) UpdateOption {
+	return func(o *updateOptions) {
+		o.onLog = onLog
+	}
+}
+
+// UpdateAndWait starts the update from the given branch, follows the update
+// log and returns once the server has restarted and answers again. The
+// restart is detected by Status reporting a lower uptime or a different start
+// time, the server time less the uptime. Errors
+// while the server is unavailable are expected and ignored; use the context
+// to bound the wait.
+func (n NZBGet) UpdateAndWait(ctx context.Context, branch UpdateBranch, opts ...UpdateOption) error {
+	options := updateOptions{interval: time.Second}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	status, err := n.Status()
+	if err != nil {
+		return err
+	}
+	last := *status
+
+	started, err := n.StartUpdate(branch)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return ErrUpdateNotStarted
+	}
+
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	lastID := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if messages, err := n.UpdateLog(lastID+1, 0); err == nil {
+			if len(messages) > 0 && messages[0].ID <= lastID {
+				// The log started over.
+				lastID = 0
+			}
+			for _, message := range messages {
+				if message.ID <= lastID {
+					continue
+				}
+				lastID = message.ID
+				if options.onLog != nil {
+					options.onLog(message)
+				}
+			}
+		}
+
+		status, err := n.Status()
+		if err != nil {
+			continue
+		}
+		if restarted(last, *status) {
+			return nil
+		}
+		last = *status
+	}
+}
+
+// restarted reports whether the server restarted between two statuses. The
+// start time may differ by a second, as both times are whole seconds, and is
+// only compared if the server reports its time.
+func restarted(before, after Status) bool {
+	if after.UpTimeSec < before.UpTimeSec {
+		return true
+	}
+	if before.ServerTime == 0 || after.ServerTime == 0 {
+		return false
+	}
+	moved := (after.ServerTime - after.UpTimeSec) - (before.ServerTime - before.UpTimeSec)
+	return moved > 1 || moved < -1
+}
@@ -0,0 +1,214 @@
+package nzbget_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const (
+	checkUpdates = `{
+  "version": "1.1",
+  "result": {
+    "stable-version": "21.1",
+    "stable-date": "2021-06-03",
+    "stable-release-notes": "https:\/\/github.com\/nzbget\/nzbget\/releases\/tag\/v21.1",
+    "testing-version": "22.0-testing-r2350",
+    "testing-date": "2021-09-12",
+    "testing-release-notes": "",
+    "devel-version": "",
+    "devel-date": "",
+    "devel-release-notes": ""
+  }
+}`
+	updateLog = `{
+  "version": "1.1",
+  "result": [
+    {
+      "ID": 1,
+      "Kind": "INFO",
+      "Time": 1589707990,
+      "Text": "Downloading update package"
+    },
+    {
+      "ID": 2,
+      "Kind": "INFO",
+      "Time": 1589707995,
+      "Text": "Restarting"
+    }
+  ]
+}`
+)
+
+func statusWithUpTime(upTime string) string {
+	return `{"version": "1.1", "result": {"UpTimeSec": ` + upTime + `}}`
+}
+
+func statusWithServerTime(serverTime, upTime string) string {
+	return `{"version": "1.1", "result": {"ServerTime": ` + serverTime + `, "UpTimeSec": ` + upTime + `}}`
+}
+
+var _ = Describe("NZBGet", func() {
+
+	Context("#CheckUpdates", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Get("/jsonrpc/checkupdates").
+					MatchParams(map[string]string{}).
+					Reply(200).
+					JSON(checkUpdates)
+			})
+
+			It("should return the available releases", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				info, err := client.CheckUpdates()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info.Version(nzbget.UpdateBranchStable)).To(Equal("21.1"))
+				Expect(info.Version(nzbget.UpdateBranchTesting)).To(Equal("22.0-testing-r2350"))
+				Expect(info.Version(nzbget.UpdateBranchDevel)).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("#StartUpdate", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		Context("successful", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "startupdate", "params": []string{"testing"}}).
+					Reply(200).
+					JSON(`{"version": "1.1", "result": true}`)
+			})
+
+			It("should start the update", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				started, err := client.StartUpdate(nzbget.UpdateBranchTesting)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+		})
+
+		Context("failed", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					Reply(200).
+					JSON(`{"version": "1.1", "error": {"name": "JSONRPCError", "code": 2, "message": "Invalid parameter"}}`)
+			})
+
+			It("should return the server error", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.StartUpdate("nightly")
+				Expect(err).To(MatchError(ContainSubstring("Invalid parameter")))
+			})
+		})
+	})
+
+	Context("#UpdateLog", func() {
+		Context("successful", func() {
+			AfterEach(func() {
+				gock.Off()
+			})
+
+			BeforeEach(func() {
+				gock.New(nzbgetURL).
+					Post("/jsonrpc").
+					JSON(map[string]interface{}{"method": "logupdate", "params": []int{0, 100}}).
+					Reply(200).
+					JSON(updateLog)
+			})
+
+			It("should return the update log", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				messages, err := client.UpdateLog(0, 100)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(messages)).To(Equal(2))
+				Expect(messages[1].Text).To(Equal("Restarting"))
+			})
+		})
+	})
+
+	Context("#UpdateAndWait", func() {
+		AfterEach(func() {
+			gock.Off()
+		})
+
+		Context("successful", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithUpTime("100"))
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("startupdate").Reply(200).JSON(`{"version": "1.1", "result": true}`)
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("logupdate").Reply(200).JSON(updateLog)
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithUpTime("101"))
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("logupdate").Reply(503)
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(503)
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("logupdate").Reply(200).JSON(`{"version": "1.1", "result": []}`)
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithUpTime("2"))
+			})
+
+			It("should follow the log and wait for the restart", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				var messages []string
+				err = client.UpdateAndWait(context.Background(), nzbget.UpdateBranchStable,
+					nzbget.WithUpdateInterval(time.Millisecond),
+					nzbget.WithUpdateLog(func(message nzbget.LogMessage) {
+						messages = append(messages, message.Text)
+					}))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(messages).To(Equal([]string{"Downloading update package", "Restarting"}))
+				Expect(gock.IsDone()).To(BeTrue())
+			})
+		})
+
+		Context("restarted while not polled", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithServerTime("1000", "10"))
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("startupdate").Reply(200).JSON(`{"version": "1.1", "result": true}`)
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("logupdate").Reply(200).JSON(updateLog)
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithServerTime("1001", "11"))
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("logupdate").Reply(200).JSON(`{"version": "1.1", "result": []}`)
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithServerTime("1100", "20"))
+			})
+
+			It("should detect the restart by the start time", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				err = client.UpdateAndWait(context.Background(), nzbget.UpdateBranchStable,
+					nzbget.WithUpdateInterval(time.Millisecond))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(gock.IsDone()).To(BeTrue())
+			})
+		})
+
+		Context("refused", func() {
+			BeforeEach(func() {
+				gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(statusWithUpTime("100"))
+				gock.New(nzbgetURL).Post("/jsonrpc").BodyString("startupdate").Reply(200).JSON(`{"version": "1.1", "result": false}`)
+			})
+
+			It("should report that the update was not started", func() {
+				client, err := nzbget.New(nzbgetURL, "user", "password")
+				Expect(err).ToNot(HaveOccurred())
+				err = client.UpdateAndWait(context.Background(), nzbget.UpdateBranchStable)
+				Expect(err).To(Equal(nzbget.ErrUpdateNotStarted))
+			})
+		})
+	})
+})
@@ -0,0 +1,336 @@
+package nzbget
+
+import (
+	"context"
+	"time"
+)
+
+// EventType is the kind of change reported by a Watcher
+type EventType string
+
+// Kinds of changes reported by a Watcher
+const (
+	// EventItemAdded is sent when a group appears in the download queue.
+	EventItemAdded EventType = "ITEM_ADDED"
+
+	// EventStatusChanged is sent when the status of a group changes.
+	EventStatusChanged EventType = "STATUS_CHANGED"
+
+	// EventItemPaused is sent when a group is paused.
+	EventItemPaused EventType = "ITEM_PAUSED"
+
+	// EventItemResumed is sent when a paused group is resumed.
+	EventItemResumed EventType = "ITEM_RESUMED"
+
+	// EventMovedToHistory is sent when an entry appears in history. It is
+	// followed by one of EventCompleted, EventFailed or EventDeleted.
+	EventMovedToHistory EventType = "MOVED_TO_HISTORY"
+
+	// EventCompleted is sent for history entries with a SUCCESS or WARNING
+	// outcome.
+	EventCompleted EventType = "COMPLETED"
+
+	// EventFailed is sent for history entries with a FAILURE outcome.
+	EventFailed EventType = "FAILED"
+
+	// EventDeleted is sent for history entries with a DELETED outcome.
+	EventDeleted EventType = "DELETED"
+
+	// EventDownloadPaused is sent when the download queue is paused.
+	EventDownloadPaused EventType = "DOWNLOAD_PAUSED"
+
+	// EventDownloadResumed is sent when the download queue is resumed.
+	EventDownloadResumed EventType = "DOWNLOAD_RESUMED"
+
+	// EventSpeedLimitChanged is sent when the download limit changes.
+	EventSpeedLimitChanged EventType = "SPEED_LIMIT_CHANGED"
+
+	// EventDisconnected is sent when the server cannot be polled anymore.
+	EventDisconnected EventType = "DISCONNECTED"
+
+	// EventReconnected is sent when the server answers again after
+	// EventDisconnected.
+	EventReconnected EventType = "RECONNECTED"
+)
+
+// Event is a change detected by a Watcher.
+type Event struct {
+	// Type is the kind of change.
+	Type EventType
+
+	// Time is the time the change was detected.
+	Time time.Time
+
+	// NZBID is the ID of the group or history entry the event is about, or
+	// 0 for events about the server.
+	NZBID int
+
+	// Name is the name of the group or history entry.
+	Name string
+
+	// Group is the current state of the group for queue events.
+	Group *FileGroup
+
+	// Entry is the history entry for history events.
+	Entry *HistoricalEntry
+
+	// Outcome is the outcome of the history entry for history events.
+	Outcome Outcome
+
+	// OldStatus and NewStatus are the statuses before and after a change of
+	// group status.
+	OldStatus, NewStatus GroupStatus
+
+	// OldLimit and NewLimit are the download limits in bytes per second
+	// before and after EventSpeedLimitChanged. 0 means unlimited.
+	OldLimit, NewLimit int
+
+	// Err is the error which caused EventDisconnected.
+	Err error
+}
+
+// WatcherOption configures a Watcher
+type WatcherOption func(*Watcher)
+
+// WithPollInterval sets how often the server is polled. The default is five
+// seconds.
+func WithPollInterval(interval time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.interval = interval
+	}
+}
+
+// WithEventBuffer sets the capacity of the event channel. The default is
+// 100. Polling blocks while the channel is full.
+func WithEventBuffer(size int) WatcherOption {
+	return func(w *Watcher) {
+		w.buffer = size
+	}
+}
+
+// Watcher polls the download queue, history and status of a server and sends
+// an Event for each change it detects.
+//
+// The state found on the first poll is taken as the baseline and produces no
+// events. History entries are reported once per watcher, identified by their
+// NZBID and HistoryTime, so a restart of NZBGet, which returns the same
+// history again, does not repeat completion events, while a download retried
+// from history is reported again when it finishes anew.
+type Watcher struct {
+	client   *NZBGet
+	interval time.Duration
+	buffer   int
+	events   chan Event
+
+	started      bool
+	disconnected bool
+	status       Status
+	groups       map[int]FileGroup
+	reported     map[historyKey]bool
+}
+
+type historyKey struct {
+	nzbID       int
+	historyTime int
+}
+
+// NewWatcher returns a watcher polling the given client.
+func NewWatcher(client *NZBGet, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		client:   client,
+		interval: 5 * time.Second,
+		buffer:   100,
+		groups:   map[int]FileGroup{},
+		reported: map[historyKey]bool{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.events = make(chan Event, w.buffer)
+	return w
+}
+
+// Events returns the channel the events are sent on. It is closed when Run
+// returns.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Run polls the server until the context is done and returns the context
+// error. Poll failures do not stop the watcher, they are reported as
+// EventDisconnected and polling continues.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if !w.poll(ctx) {
+			return ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches the server state once and sends the events for the changes.
+// It returns false if the context was done while sending.
+func (w *Watcher) poll(ctx context.Context) bool {
+	status, groups, history, err := w.fetch()
+	if err != nil {
+		if !w.disconnected && w.started {
+			w.disconnected = true
+			return w.send(ctx, Event{Type: EventDisconnected, Err: err})
+		}
+		w.disconnected = true
+		return true
+	}
+
+	var events []Event
+	if w.disconnected && w.started {
+		events = append(events, Event{Type: EventReconnected})
+	}
+	w.disconnected = false
+
+	if !w.started {
+		w.baseline(*status, groups, history)
+		return true
+	}
+	events = append(events, w.diffStatus(*status)...)
+	events = append(events, w.diffGroups(groups)...)
+	events = append(events, w.diffHistory(history)...)
+	for _, event := range events {
+		if !w.send(ctx, event) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *Watcher) fetch() (*Status, []FileGroup, []HistoricalEntry, error) {
+	status, err := w.client.Status()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	groups, err := w.client.FileGroups()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	history, err := w.client.History()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return status, groups, history, nil
+}
+
+func (w *Watcher) baseline(status Status, groups []FileGroup, history []HistoricalEntry) {
+	w.started = true
+	w.status = status
+	for _, group := range groups {
+		w.groups[group.NZBID] = group
+	}
+	for _, entry := range history {
+		w.reported[historyKey{entry.NZBID, entry.HistoryTime}] = true
+	}
+}
+
+func (w *Watcher) diffStatus(status Status) []Event {
+	var events []Event
+	paused := status.DownloadPaused || status.Download2Paused
+	wasPaused := w.status.DownloadPaused || w.status.Download2Paused
+	if paused && !wasPaused {
+		events = append(events, Event{Type: EventDownloadPaused})
+	} else if !paused && wasPaused {
+		events = append(events, Event{Type: EventDownloadResumed})
+	}
+	if status.DownloadLimit != w.status.DownloadLimit {
+		events = append(events, Event{
+			Type:     EventSpeedLimitChanged,
+			OldLimit: w.status.DownloadLimit,
+			NewLimit: status.DownloadLimit,
+		})
+	}
+	w.status = status
+	return events
+}
+
+func (w *Watcher) diffGroups(groups []FileGroup) []Event {
+	var events []Event
+	current := make(map[int]FileGroup, len(groups))
+	for i := range groups {
+		group := groups[i]
+		current[group.NZBID] = group
+		previous, known := w.groups[group.NZBID]
+		base := Event{NZBID: group.NZBID, Name: group.NZBName, Group: &groups[i]}
+		if !known {
+			added := base
+			added.Type = EventItemAdded
+			added.NewStatus = group.Status
+			events = append(events, added)
+			continue
+		}
+		if previous.Status == group.Status {
+			continue
+		}
+		changed := base
+		changed.Type = EventStatusChanged
+		changed.OldStatus = previous.Status
+		changed.NewStatus = group.Status
+		events = append(events, changed)
+		if group.Status.IsPaused() {
+			paused := changed
+			paused.Type = EventItemPaused
+			events = append(events, paused)
+		} else if previous.Status.IsPaused() {
+			resumed := changed
+			resumed.Type = EventItemResumed
+			events = append(events, resumed)
+		}
+	}
+	w.groups = current
+	return events
+}
+
+func (w *Watcher) diffHistory(history []HistoricalEntry) []Event {
+	var events []Event
+	// Only the entries still in history are remembered, so the set does not
+	// grow beyond the size of the history.
+	reported := make(map[historyKey]bool, len(history))
+	// History is sent newest first; report in the order entries were added.
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := &history[i]
+		key := historyKey{entry.NZBID, entry.HistoryTime}
+		reported[key] = true
+		if w.reported[key] {
+			continue
+		}
+		outcome := entry.Outcome()
+		base := Event{NZBID: entry.NZBID, Name: entry.Name, Entry: entry, Outcome: outcome}
+		moved := base
+		moved.Type = EventMovedToHistory
+		result := base
+		switch outcome.Category {
+		case OutcomeFailure:
+			result.Type = EventFailed
+		case OutcomeDeleted:
+			result.Type = EventDeleted
+		default:
+			result.Type = EventCompleted
+		}
+		events = append(events, moved, result)
+	}
+	w.reported = reported
+	return events
+}
+
+func (w *Watcher) send(ctx context.Context, event Event) bool {
+	event.Time = time.Now()
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,120 @@
+package nzbget_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/billtomturner/go-nzbget-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const (
+	watchedHistory = `{"version": "1.1", "result": [
+  {"NZBID": 1, "Name": "My_File_1", "HistoryTime": 1589707990, "Status": "SUCCESS/ALL",
+   "ParStatus": "SUCCESS", "UnpackStatus": "SUCCESS", "MoveStatus": "SUCCESS", "ScriptStatus": "NONE",
+   "DeleteStatus": "NONE", "MarkStatus": "NONE", "URLStatus": "NONE", "Health": 1000}
+]}`
+)
+
+func mockPoll(status, groups, history string) {
+	gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(200).JSON(status)
+	gock.New(nzbgetURL).Get("/jsonrpc/listgroups").Reply(200).JSON(groups)
+	gock.New(nzbgetURL).Get("/jsonrpc/history").Reply(200).JSON(history)
+}
+
+func eventTypes(events []nzbget.Event) []nzbget.EventType {
+	types := make([]nzbget.EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+var _ = Describe("Watcher", func() {
+	var (
+		ctx      context.Context
+		cancel   context.CancelFunc
+		done     chan error
+		received []nzbget.Event
+	)
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive())
+		gock.Off()
+	})
+
+	BeforeEach(func() {
+		mockPoll(`{"version": "1.1", "result": {"DownloadLimit": 0, "UpTimeSec": 100}}`,
+			`{"version": "1.1", "result": [{"NZBID": 1, "NZBName": "My_File_1", "Status": "DOWNLOADING"}]}`,
+			`{"version": "1.1", "result": []}`)
+		mockPoll(`{"version": "1.1", "result": {"DownloadPaused": true, "DownloadLimit": 1048576, "UpTimeSec": 101}}`,
+			`{"version": "1.1", "result": [
+				{"NZBID": 1, "NZBName": "My_File_1", "Status": "PAUSED"},
+				{"NZBID": 2, "NZBName": "My_File_2", "Status": "PAUSED"}
+			]}`,
+			`{"version": "1.1", "result": []}`)
+		mockPoll(`{"version": "1.1", "result": {"DownloadLimit": 1048576, "UpTimeSec": 102}}`,
+			`{"version": "1.1", "result": [{"NZBID": 2, "NZBName": "My_File_2", "Status": "PAUSED"}]}`,
+			watchedHistory)
+		gock.New(nzbgetURL).Get("/jsonrpc/status").Reply(500)
+		mockPoll(`{"version": "1.1", "result": {"DownloadLimit": 1048576, "UpTimeSec": 1}}`,
+			`{"version": "1.1", "result": [{"NZBID": 2, "NZBName": "My_File_2", "Status": "DOWNLOADING"}]}`,
+			watchedHistory)
+
+		client, err := nzbget.New(nzbgetURL, "user", "password")
+		Expect(err).ToNot(HaveOccurred())
+		watcher := nzbget.NewWatcher(client, nzbget.WithPollInterval(time.Millisecond))
+		ctx, cancel = context.WithCancel(context.Background())
+		done = start(ctx, watcher)
+
+		received = receive(watcher.Events(), 13).([]nzbget.Event)
+	})
+
+	It("should report the changes after the baseline", func() {
+		Expect(eventTypes(received)).To(Equal([]nzbget.EventType{
+			nzbget.EventDownloadPaused,
+			nzbget.EventSpeedLimitChanged,
+			nzbget.EventStatusChanged,
+			nzbget.EventItemPaused,
+			nzbget.EventItemAdded,
+			nzbget.EventDownloadResumed,
+			nzbget.EventMovedToHistory,
+			nzbget.EventCompleted,
+			nzbget.EventDisconnected,
+			nzbget.EventReconnected,
+			nzbget.EventStatusChanged,
+			nzbget.EventItemResumed,
+			nzbget.EventDisconnected,
+		}))
+	})
+
+	It("should describe the changes", func() {
+		Expect(received[1]).To(MatchFields(IgnoreExtras, Fields{
+			"OldLimit": Equal(0),
+			"NewLimit": Equal(1048576),
+		}))
+		Expect(received[2]).To(MatchFields(IgnoreExtras, Fields{
+			"NZBID":     Equal(1),
+			"Name":      Equal("My_File_1"),
+			"OldStatus": Equal(nzbget.GroupStatusDownloading),
+			"NewStatus": Equal(nzbget.GroupStatusPaused),
+		}))
+		Expect(received[7].Outcome.Category).To(Equal(nzbget.OutcomeSuccess))
+		Expect(received[7].Entry.NZBID).To(Equal(1))
+		Expect(received[8].Err).To(HaveOccurred())
+	})
+
+	It("should not repeat completions after a restart", func() {
+		completions := 0
+		for _, event := range received {
+			if event.Type == nzbget.EventCompleted {
+				completions++
+			}
+		}
+		Expect(completions).To(Equal(1))
+	})
+})